@@ -0,0 +1,68 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "context"
+
+const (
+	// AuditKey is the key that marks audit records (see Audit).
+	AuditKey = "audit"
+	// ActionKey is the key that has the audited action as value.
+	ActionKey = "action"
+	// ActorKey is the key that has who performed the audited action as value.
+	ActorKey = "actor"
+	// ResourceKey is the key that has the resource the audited action was performed on as
+	// value.
+	ResourceKey = "resource"
+	// AuditIncompleteKey is the key that marks audit records missing required fields.
+	AuditIncompleteKey = "audit_incomplete"
+)
+
+// AuditContext returns a new logging context for an audit trail. It's like Context, but
+// records are JSON encoded unless opts say otherwise.
+func AuditContext(parent context.Context, opts ...ContextOption) context.Context {
+	return Context(parent, append([]ContextOption{WithJSONEncoding()}, opts...)...)
+}
+
+// WithActor adds who performed an audited action to the log record.
+func WithActor(actor string) Option {
+	return WithField(ActorKey, actor)
+}
+
+// WithResource adds the resource an audited action was performed on to the log record.
+func WithResource(resource string) Option {
+	return WithField(ResourceKey, resource)
+}
+
+// Audit logs an audit record of action at InfoLevel, marked with "audit": true. Audit records
+// are written whatever the level of the logging context (see WithForce), so that the audit
+// trail doesn't depend on it. They must carry a non-empty actor and resource (see WithActor and
+// WithResource); records missing either are still logged, but marked with
+// "audit_incomplete": true.
+func Audit(ctx context.Context, action string, opts ...Option) {
+	opts = append([]Option{
+		WithField(AuditKey, true),
+		WithField(ActionKey, action),
+		WithForce(),
+	}, opts...)
+
+	Info(skipCallers(ctx, 1), "audit", append(opts, func(o *options) {
+		for _, key := range []string{ActorKey, ResourceKey} {
+			if v, ok := o.fields[key]; !ok || v == "" {
+				o.fields[AuditIncompleteKey] = true
+			}
+		}
+	})...)
+}
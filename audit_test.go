@@ -0,0 +1,77 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Audit(ctx, "delete", WithActor("alice"), WithResource("orders/1"), WithField("a", 1))
+	Audit(ctx, "delete", WithActor("alice"))
+	Audit(ctx, "delete", WithActor(""), WithResource("orders/1"))
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	complete := records[0]
+	if complete[AuditKey] != true || complete[ActionKey] != "delete" ||
+		complete[ActorKey] != "alice" || complete[ResourceKey] != "orders/1" ||
+		complete["a"] != 1.0 || complete["severity"] != "INFO" {
+		t.Errorf("unexpected audit record %v", complete)
+	}
+
+	if _, ok := complete[AuditIncompleteKey]; ok {
+		t.Errorf("expected a complete audit record, got %v", complete)
+	}
+
+	for _, r := range records[1:] {
+		if r[AuditKey] != true || r[AuditIncompleteKey] != true {
+			t.Errorf("expected an incomplete audit record, got %v", r)
+		}
+	}
+}
+
+func TestAuditAboveLevel(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(WarnLevel))
+
+	Audit(ctx, "delete", WithActor("alice"), WithResource("orders/1"))
+	Info(ctx, "not audited")
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if r := records[0]; r[ActionKey] != "delete" || r["severity"] != "INFO" {
+		t.Errorf("unexpected audit record %v", r)
+	}
+}
+
+func TestAuditContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	ctx := AuditContext(context.Background(), WithRotatingFile(path, 0, 0, 0))
+	t.Cleanup(func() { _ = Close(ctx) })
+
+	Audit(ctx, "login", WithActor("alice"), WithResource("console"))
+
+	// readRecords fails unless the record is JSON encoded
+	if r := readRecords(t, path); len(r) != 1 || r[0][ActionKey] != "login" {
+		t.Errorf("unexpected records %v", r)
+	}
+}
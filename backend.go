@@ -0,0 +1,287 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Backend writes the records of a logging context. By default logging contexts write with zap;
+// WithBackend installs another backend, eg. a lighter one for embedded builds or a
+// MemoryBackend in tests.
+//
+// Backends must be safe for concurrent use. The fields they're given are plain values: errors
+// are given as their message, and fields nested with WithNamespace as a map[string]any, so
+// that backends don't depend on zap.
+type Backend interface {
+	// Enabled reports whether records at level are written.
+	Enabled(level Level) bool
+	// Write writes a record at level with fields, regardless of the backend's level: the
+	// logging functions check Enabled first, except for forced records (see WithForce).
+	// PanicLevel and FatalLevel records are then followed by a panic and by exiting the
+	// process respectively, either by Write (as zap does) or by the logging function.
+	Write(level Level, msg string, fields Fields)
+	// With returns a backend that adds fields to every record it writes. Both backends share
+	// their level.
+	With(fields Fields) Backend
+	// Level returns the minimum level of the records written.
+	Level() Level
+	// SetLevel changes the minimum level of the records written.
+	SetLevel(level Level)
+	// Sync flushes any buffered records.
+	Sync() error
+}
+
+// WithBackend makes the logging context write its records to backend rather than with zap.
+// The backend's level is set to the context's level (see WithLevel), and the context's fields
+// are added to it with With. Close syncs the backend.
+//
+// The options configuring the output (eg. WithOutputPath, WithJSONEncoding, WithHooks) are
+// ignored, and so are the functions that only apply to zap (ContextWithClock,
+// ContextWithIndependentLevel, ContextWithEntryCallback, Combine and ConfigFromContext).
+func WithBackend(backend Backend) ContextOption {
+	return func(o *contextOptions) {
+		o.backend = backend
+	}
+}
+
+// backendOf returns the backend of the logging context ctx.
+func backendOf(ctx context.Context) (Backend, bool) {
+	b, ok := ctx.Value(loggerKey).(Backend)
+
+	return b, ok
+}
+
+// zapBackendOf returns the backend of the logging context ctx if it writes with zap.
+func zapBackendOf(ctx context.Context) (*zapBackend, bool) {
+	b, ok := ctx.Value(loggerKey).(*zapBackend)
+
+	return b, ok
+}
+
+// withZapBackend returns a new logging context derived from parent with fn applied to its
+// zap backend, and to the backend without the context's fields it may be rebuilt from (see
+// withZapFields). fn is given the context fields of the backend it's applied to. It returns
+// false if parent is not a logging context writing with zap.
+func withZapBackend(
+	parent context.Context, fn func(b *zapBackend, fields []zap.Field) *zapBackend,
+) (context.Context, bool) {
+	b, ok := zapBackendOf(parent)
+	if !ok {
+		return parent, false
+	}
+
+	fields, _ := parent.Value(contextFieldsKey).([]zap.Field)
+
+	ctx := context.WithValue(parent, loggerKey, fn(b, fields))
+
+	if base, ok := parent.Value(baseKey).(*zapBackend); ok {
+		ctx = context.WithValue(ctx, baseKey, fn(base, nil))
+	}
+
+	return ctx, true
+}
+
+// writeZap writes a record with fields built with zap to b, as-is if b writes with zap.
+func writeZap(b Backend, level Level, msg string, fields []zap.Field) {
+	if zb, ok := b.(*zapBackend); ok {
+		zb.logger.Log(zapcore.Level(level), msg, fields...)
+
+		return
+	}
+
+	b.Write(level, msg, fieldsOf(fields))
+}
+
+// withZap returns b with fields built with zap added, as-is if b writes with zap.
+func withZap(b Backend, fields []zap.Field) Backend {
+	if zb, ok := b.(*zapBackend); ok {
+		return &zapBackend{logger: zb.logger.With(fields...), level: zb.level}
+	}
+
+	return b.With(fieldsOf(fields))
+}
+
+// fieldsOf returns the values of fields built with zap, as encoded by zap.
+func fieldsOf(fields []zap.Field) Fields {
+	enc := zapcore.NewMapObjectEncoder()
+
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+
+	return enc.Fields
+}
+
+// zapFields returns fields as zap fields, sorted by key.
+func zapFields(fields Fields) []zap.Field {
+	zf := make([]zap.Field, 0, len(fields))
+
+	for _, k := range slices.Sorted(maps.Keys(fields)) {
+		zf = append(zf, zap.Any(k, fields[k]))
+	}
+
+	return zf
+}
+
+// zapBackend is the default backend, writing with a zap logger.
+type zapBackend struct {
+	logger *zap.Logger
+	// level is nil if the logger's level can't be changed (see Combine)
+	level *zap.AtomicLevel
+}
+
+func (b *zapBackend) Enabled(level Level) bool {
+	return b.logger.Level().Enabled(zapcore.Level(level))
+}
+
+func (b *zapBackend) Write(level Level, msg string, fields Fields) {
+	writeZap(b, level, msg, zapFields(fields))
+}
+
+func (b *zapBackend) With(fields Fields) Backend {
+	return withZap(b, zapFields(fields))
+}
+
+func (b *zapBackend) Level() Level {
+	if b.level == nil {
+		return Level(b.logger.Level())
+	}
+
+	return Level(b.level.Level())
+}
+
+func (b *zapBackend) SetLevel(level Level) {
+	if b.level != nil {
+		b.level.SetLevel(zapcore.Level(level))
+	}
+}
+
+func (b *zapBackend) Sync() error {
+	return b.logger.Sync()
+}
+
+// force returns a backend writing records of any level, bypassing b's level and the levels
+// of its cores.
+func (b *zapBackend) force() *zapBackend {
+	return b.withOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, level: zap.LevelEnablerFunc(func(zapcore.Level) bool {
+			return true
+		})}
+	}))
+}
+
+// withOptions returns a backend sharing b's level whose logger has opts applied.
+func (b *zapBackend) withOptions(opts ...zap.Option) *zapBackend {
+	return &zapBackend{logger: b.logger.WithOptions(opts...), level: b.level}
+}
+
+// MemoryRecord is a record written to a MemoryBackend.
+type MemoryRecord struct {
+	Level  Level
+	Msg    string
+	Fields Fields
+}
+
+// MemoryBackend is a Backend keeping records in memory, eg. to assert on them in tests.
+type MemoryBackend struct {
+	shared *memoryRecords
+	fields Fields
+}
+
+type memoryRecords struct {
+	mu      sync.Mutex
+	level   Level
+	records []MemoryRecord
+}
+
+// NewMemoryBackend returns an empty MemoryBackend writing records at level or above.
+func NewMemoryBackend(level Level) *MemoryBackend {
+	return &MemoryBackend{shared: &memoryRecords{level: level}}
+}
+
+// Records returns the records written so far, oldest first.
+func (b *MemoryBackend) Records() []MemoryRecord {
+	b.shared.mu.Lock()
+	defer b.shared.mu.Unlock()
+
+	return slices.Clone(b.shared.records)
+}
+
+func (b *MemoryBackend) Enabled(level Level) bool {
+	return level >= b.Level()
+}
+
+func (b *MemoryBackend) Write(level Level, msg string, fields Fields) {
+	all := make(Fields, len(b.fields)+len(fields))
+	maps.Copy(all, b.fields)
+	maps.Copy(all, fields)
+
+	b.shared.mu.Lock()
+	defer b.shared.mu.Unlock()
+
+	b.shared.records = append(b.shared.records, MemoryRecord{
+		Level:  level,
+		Msg:    msg,
+		Fields: all,
+	})
+}
+
+func (b *MemoryBackend) With(fields Fields) Backend {
+	all := maps.Clone(b.fields)
+	if all == nil {
+		all = make(Fields, len(fields))
+	}
+
+	maps.Copy(all, fields)
+
+	return &MemoryBackend{shared: b.shared, fields: all}
+}
+
+func (b *MemoryBackend) Level() Level {
+	b.shared.mu.Lock()
+	defer b.shared.mu.Unlock()
+
+	return b.shared.level
+}
+
+func (b *MemoryBackend) SetLevel(level Level) {
+	b.shared.mu.Lock()
+	defer b.shared.mu.Unlock()
+
+	b.shared.level = level
+}
+
+func (*MemoryBackend) Sync() error {
+	return nil
+}
+
+// terminate panics after a PanicLevel record and exits after a FatalLevel record, for the
+// backends that don't.
+func terminate(level Level, msg string) {
+	switch level {
+	case PanicLevel:
+		panic(msg)
+	case FatalLevel:
+		os.Exit(1)
+	}
+}
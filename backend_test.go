@@ -0,0 +1,146 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithBackend(t *testing.T) {
+	backend := NewMemoryBackend(ErrorLevel)
+
+	ctx, err := NewContext(context.Background(),
+		WithBackend(backend), WithLevel(DebugLevel), WithVersion("v1", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = ContextWithField(ctx, "a", 1)
+
+	Debug(ctx, "debug", WithField("b", 2))
+
+	SetLevel(ctx, WarnLevel)
+	Info(ctx, "info")
+
+	if Enabled(ctx, InfoLevel) || !Enabled(ctx, WarnLevel) {
+		t.Error("expected the level to be changed on the backend")
+	}
+
+	records := backend.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %v", records)
+	}
+
+	r := records[0]
+
+	if r.Level != DebugLevel || r.Msg != "debug" {
+		t.Errorf("unexpected record: %+v", r)
+	}
+
+	want := Fields{VersionKey: "v1", "a": int64(1), "b": int64(2)}
+
+	for k, v := range want {
+		if r.Fields[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, r.Fields[k])
+		}
+	}
+
+	if _, ok := ConfigFromContext(ctx); ok {
+		t.Error("expected no configuration for a custom backend")
+	}
+
+	if err := Close(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWithBackendPanic(t *testing.T) {
+	backend := NewMemoryBackend(InfoLevel)
+
+	ctx, err := NewContext(context.Background(), WithBackend(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+
+		if records := backend.Records(); len(records) != 1 || records[0].Level != PanicLevel {
+			t.Errorf("expected the record to be written before panicking, got %v", records)
+		}
+	}()
+
+	Panic(ctx, "boom")
+}
+
+func TestWithBackendZapOnly(t *testing.T) {
+	ctx, err := NewContext(context.Background(), WithBackend(NewMemoryBackend(InfoLevel)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ContextWithClock(ctx, time.Now) != ctx || ContextWithIndependentLevel(ctx, DebugLevel) != ctx {
+		t.Error("expected the zap-only functions to return the context as-is")
+	}
+
+	if Combine(ctx) != ctx {
+		t.Error("expected a context without zap contexts to be returned as-is")
+	}
+}
+
+func TestWithBackendFields(t *testing.T) {
+	backend := NewMemoryBackend(InfoLevel)
+
+	ctx, err := NewContext(context.Background(), WithBackend(backend))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Info(ctx, "x",
+		WithError(errors.New("boom")),
+		WithNamespace("http"),
+		WithField("method", "GET"),
+	)
+
+	records := backend.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %v", records)
+	}
+
+	if got := records[0].Fields; got[DefaultErrorKey] != "boom" ||
+		fmt.Sprint(got["http"]) != "map[method:GET]" {
+		t.Errorf("expected plain values, got %v", got)
+	}
+}
+
+func TestZapBackendFields(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	b, _ := backendOf(ctx)
+	b.With(Fields{"a": 1}).Write(WarnLevel, "x", Fields{"b": "c"})
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if r := records[0]; r["severity"] != "WARN" || r["a"] != 1.0 || r["b"] != "c" {
+		t.Errorf("unexpected record: %v", r)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"maps"
+
+	"go.uber.org/zap"
+)
+
+// Record is a log record for LogBatch.
+type Record struct {
+	Msg    string
+	Fields Fields
+	Err    error
+}
+
+// LogBatch logs records at level. It's equivalent to calling Log for every record but
+// cheaper: the level is checked once and the buffer used to assemble fields is reused.
+//
+// At PanicLevel (or FatalLevel) only the first record is logged, with the stack trace, before
+// panicking (or exiting).
+func LogBatch(ctx context.Context, level Level, records []Record) {
+	b, ok := backendOf(ctx)
+	if !ok {
+		return
+	}
+
+	if !b.Enabled(level) {
+		for _, r := range records {
+			explain(ctx, level, r.Msg, reasonBelowLevel)
+		}
+
+		return
+	}
+
+	// extractors and defaults add their fields to the record's, which belong to the caller
+	rc, _ := ctx.Value(recordKey).(*recordConfig)
+	extracting := rc != nil && (len(rc.extractors) > 0 || len(rc.defaults) > 0)
+
+	var zf []zap.Field
+
+	for _, r := range records {
+		o := options{fields: r.Fields, err: r.Err}
+		if extracting {
+			o.fields = maps.Clone(r.Fields)
+		}
+
+		zf = appendFields(zf[:0], ctx, &o)
+		if level >= PanicLevel {
+			zf = append(zf, stacktrace(ctx))
+		}
+
+		writeZap(b, level, r.Msg, zf)
+		terminate(level, r.Msg)
+	}
+}
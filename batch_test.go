@@ -0,0 +1,106 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLogBatch(t *testing.T) {
+	ctx, read := newFileContext(t, WithAutoComponent())
+	ctx = ContextWithField(ctx, "a", 1)
+
+	fields := Fields{"b": 2}
+
+	LogBatch(ctx, WarnLevel, []Record{
+		{Msg: "x", Fields: fields},
+		{Msg: "y", Err: errors.New("failed")},
+	})
+
+	Warn(ctx, "x", WithFields(fields))
+	Warn(ctx, "y", WithError(errors.New("failed")))
+
+	records := read()
+	requireRecords(t, records, 4)
+
+	for i := range 2 {
+		if !reflect.DeepEqual(records[i], records[i+2]) {
+			t.Errorf("expected %v to match Warn's %v", records[i], records[i+2])
+		}
+	}
+
+	if len(fields) != 1 {
+		t.Errorf("expected the record's fields not to be modified, got %v", fields)
+	}
+}
+
+func TestLogBatchBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	ctx, read := newFileContext(t, WithExplain(&buf))
+
+	LogBatch(ctx, DebugLevel, []Record{{Msg: "x"}, {Msg: "y"}})
+
+	if len(read()) != 0 {
+		t.Error("expected no records below level")
+	}
+
+	if got, want := buf.String(), "suppressed DEBUG \"x\": below level\n"+
+		"suppressed DEBUG \"y\": below level\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func BenchmarkLogBatch(b *testing.B) {
+	records := make([]Record, 100)
+	for i := range records {
+		records[i] = Record{Msg: "imported", Fields: Fields{"id": i, "status": "ok"}}
+	}
+
+	newContext := func(b *testing.B) context.Context {
+		ctx := Context(context.Background(),
+			WithJSONEncoding(),
+			WithRotatingFile(filepath.Join(b.TempDir(), "bench.log"), 0, 0, 0),
+		)
+		b.Cleanup(func() { _ = Close(ctx) })
+
+		return ctx
+	}
+
+	b.Run("Info", func(b *testing.B) {
+		ctx := newContext(b)
+		b.ReportAllocs()
+
+		for range b.N {
+			for _, r := range records {
+				Info(ctx, r.Msg, WithFields(r.Fields))
+			}
+		}
+	})
+
+	b.Run("LogBatch", func(b *testing.B) {
+		ctx := newContext(b)
+		b.ReportAllocs()
+
+		for range b.N {
+			LogBatch(ctx, InfoLevel, records)
+		}
+	})
+}
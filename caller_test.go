@@ -0,0 +1,157 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// previousLine returns the location, as WithCaller reports it, of the line preceding the
+// call to previousLine.
+func previousLine() string {
+	_, file, line, _ := runtime.Caller(1)
+
+	return fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(file)), filepath.Base(file), line-1)
+}
+
+// nextLine is like previousLine for the line following the call to nextLine.
+func nextLine() string {
+	_, file, line, _ := runtime.Caller(1)
+
+	return fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(file)), filepath.Base(file), line+1)
+}
+
+func TestWithCaller(t *testing.T) {
+	ctx, read := newFileContext(t, WithCaller(), WithLevel(DebugLevel))
+
+	var want []string
+
+	Debug(ctx, "x")
+	want = append(want, previousLine())
+	Info(ctx, "x")
+	want = append(want, previousLine())
+	Log(ctx, WarnLevel, "x")
+	want = append(want, previousLine())
+	LogBatch(ctx, ErrorLevel, []Record{{Msg: "x"}})
+	want = append(want, previousLine())
+
+	records := read()
+	requireRecords(t, records, len(want))
+
+	for i, r := range records {
+		if got := r[CallerKey]; got != want[i] {
+			t.Errorf("expected %s, got %v", want[i], got)
+		}
+	}
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	ctx, read := newFileContext(t, WithCaller(), WithCallerSkip(1))
+
+	logVia := func(ctx context.Context) {
+		Info(ctx, "x")
+	}
+
+	logVia(ctx)
+	want := previousLine()
+
+	if got := read()[0][CallerKey]; got != want {
+		t.Errorf("expected the wrapper's caller %s, got %v", want, got)
+	}
+}
+
+func TestDeprecatedWithCaller(t *testing.T) {
+	ctx, read := newFileContext(t, WithCaller())
+
+	oldAPI := func(ctx context.Context) {
+		Deprecated(ctx, "oldAPI is deprecated")
+	}
+
+	oldAPI(ctx)
+	want := previousLine()
+
+	if got := read()[0][CallerKey]; got != want {
+		t.Errorf("expected the deprecated function's caller %s, got %v", want, got)
+	}
+}
+
+func TestWithCallerHelpers(t *testing.T) {
+	ctx, read := newFileContext(t, WithCaller())
+
+	expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	var want []string
+
+	LogRetry(ctx, 1, 2, time.Second, errors.New("timeout"))
+	want = append(want, previousLine())
+	LogRetry(ctx, 2, 2, 0, nil)
+	want = append(want, previousLine())
+	StartTimer(ctx, "timed")()
+	want = append(want, previousLine())
+	Audit(ctx, "delete", WithActor("alice"), WithResource("orders/1"))
+	want = append(want, previousLine())
+	DeadlineWarning(expired, time.Second, "late")
+	want = append(want, previousLine())
+	_ = LogError(ctx, "failed", errors.New("boom"))
+	want = append(want, previousLine())
+
+	canonical, done := CanonicalContext(ctx)
+	done("done")
+	want = append(want, previousLine())
+
+	StdLogger(canonical, InfoLevel).Println("std")
+	want = append(want, previousLine())
+
+	w := Writer(ctx, InfoLevel)
+	_, _ = w.Write([]byte("line\n"))
+	want = append(want, previousLine())
+	_, _ = w.Write([]byte("partial"))
+	_ = w.Close()
+	want = append(want, previousLine())
+
+	records := read()
+	requireRecords(t, records, len(want))
+
+	for i, r := range records {
+		if got := r[CallerKey]; got != want[i] {
+			t.Errorf("record %d (%s): expected %s, got %v", i, r["msg"], want[i], got)
+		}
+	}
+}
+
+func TestRecoverWithCaller(t *testing.T) {
+	ctx, read := newFileContext(t, WithCaller())
+
+	var want string
+
+	func() {
+		defer Recover(ctx)
+
+		want = nextLine()
+		panic("boom")
+	}()
+
+	// the record is annotated with the location of the panic
+	if got := read()[0][CallerKey]; got != want {
+		t.Errorf("expected %s, got %v", want, got)
+	}
+}
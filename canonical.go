@@ -0,0 +1,86 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// canonicalLine accumulates the fields of a canonical log line.
+type canonicalLine struct {
+	mu     sync.Mutex
+	fields Fields
+}
+
+// CanonicalContext returns a logging context derived from parent along with a function that
+// emits a single "canonical" log line at InfoLevel carrying every field added with
+// ContextWithField or ContextWithFields to the returned context or any context derived from
+// it, plus the given options. It is typically deferred at the start of a request:
+//
+//	ctx, done := clog.CanonicalContext(ctx)
+//	defer done("request completed")
+//
+// If parent is not a logging context then parent is returned as-is and the function is a
+// no-op.
+func CanonicalContext(parent context.Context) (context.Context, func(string, ...Option)) {
+	if _, ok := backendOf(parent); !ok {
+		return parent, func(string, ...Option) {}
+	}
+
+	line := &canonicalLine{fields: make(Fields)}
+
+	emit := func(msg string, opts ...Option) {
+		line.mu.Lock()
+		fields := maps.Clone(line.fields)
+		line.mu.Unlock()
+
+		Info(skipCallers(parent, 1), msg, append([]Option{WithFields(fields)}, opts...)...)
+	}
+
+	return context.WithValue(parent, canonicalKey, line), emit
+}
+
+// accumulate records fields into the canonical line of ctx, if any.
+func accumulate(ctx context.Context, fields Fields) {
+	line, ok := ctx.Value(canonicalKey).(*canonicalLine)
+	if !ok {
+		return
+	}
+
+	line.mu.Lock()
+	defer line.mu.Unlock()
+
+	maps.Copy(line.fields, fields)
+}
+
+// accumulateZapFields is like accumulate for zap fields, which are recorded as encoded.
+func accumulateZapFields(ctx context.Context, fields []zap.Field) {
+	if _, ok := ctx.Value(canonicalKey).(*canonicalLine); !ok {
+		return
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+
+	accumulate(ctx, enc.Fields)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCanonicalContext(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	ctx, done := CanonicalContext(ctx)
+
+	ctx = ContextWithField(ctx, "user", "u1")
+	child := ContextWithFields(ctx, Fields{"route": "/x", "status": 200})
+
+	Info(child, "handling")
+
+	done("request completed", WithField("duration_ms", 12))
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	summary := records[1]
+
+	want := map[string]any{
+		"msg":         "request completed",
+		"user":        "u1",
+		"route":       "/x",
+		"status":      200.0,
+		"duration_ms": 12.0,
+	}
+
+	for k, v := range want {
+		if summary[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, summary[k])
+		}
+	}
+}
+
+func TestCanonicalContextNotLoggingContext(t *testing.T) {
+	parent := context.Background()
+
+	ctx, done := CanonicalContext(parent)
+	if ctx != parent {
+		t.Error("expected the parent to be returned as-is")
+	}
+
+	done("nothing")
+}
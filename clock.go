@@ -0,0 +1,54 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WithClock sets the time source used to timestamp log records (time.Now by default).
+func WithClock(clock func() time.Time) ContextOption {
+	return func(o *contextOptions) {
+		o.clock = clock
+	}
+}
+
+// ContextWithClock returns a new logging context derived from parent whose log records are
+// timestamped with clock (eg. the event time when replaying historical events). The parent
+// context keeps its own time source.
+//
+// If parent is not a logging context, or doesn't write with zap (see WithBackend), then parent
+// is returned as-is.
+func ContextWithClock(parent context.Context, clock func() time.Time) context.Context {
+	ctx, _ := withZapBackend(parent, func(b *zapBackend, _ []zap.Field) *zapBackend {
+		return b.withOptions(zap.WithClock(funcClock(clock)))
+	})
+
+	return ctx
+}
+
+// funcClock adapts a function to a zapcore.Clock.
+type funcClock func() time.Time
+
+func (c funcClock) Now() time.Time {
+	return c()
+}
+
+func (funcClock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
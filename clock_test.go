@@ -0,0 +1,119 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithClock(t *testing.T) {
+	fixed := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	ctx, read := newFileContext(t, WithTimeKey("time"), WithClock(func() time.Time { return fixed }))
+
+	Info(ctx, "x")
+
+	if got := read()[0]["time"]; got != "2001-02-03T04:05:06Z" {
+		t.Errorf("unexpected time %v", got)
+	}
+}
+
+func TestContextWithClock(t *testing.T) {
+	event := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	ctx, read := newFileContext(t, WithTimeKey("time"))
+
+	replay := ContextWithClock(ctx, func() time.Time { return event })
+
+	Info(replay, "replayed")
+	Info(ctx, "live")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if got := records[0]["time"]; got != "2001-02-03T04:05:06Z" {
+		t.Errorf("expected the event time in the replayed context, got %v", got)
+	}
+
+	live, err := time.Parse(time.RFC3339, records[1]["time"].(string))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if time.Since(live) > time.Minute {
+		t.Errorf("expected the real time in the parent context, got %v", live)
+	}
+}
+
+func TestContextWithClockNotLoggingContext(t *testing.T) {
+	parent := context.Background()
+
+	if ctx := ContextWithClock(parent, time.Now); ctx != parent {
+		t.Error("expected the parent to be returned as-is")
+	}
+
+}
+
+func TestWithTimeLayout(t *testing.T) {
+	fixed := time.Date(2001, 2, 3, 4, 5, 6, 7e6, time.UTC)
+	clock := WithClock(func() time.Time { return fixed })
+
+	ctx, read := newFileContext(t, WithTimeKey("time"), WithTimeLayout("2006-01-02 15:04:05.000"),
+		clock)
+
+	Info(ctx, "x")
+
+	if got := read()[0]["time"]; got != "2001-02-03 04:05:06.007" {
+		t.Errorf("unexpected time: %v", got)
+	}
+
+	ctx, read = newFileContext(t, WithTimeLayout(time.Kitchen), WithNoTimeKey(), clock)
+
+	Info(ctx, "x")
+
+	if r := read()[0]; len(r) != 2 {
+		t.Errorf("expected no time, got %v", r)
+	}
+}
+
+func TestWithTimeLayoutEmpty(t *testing.T) {
+	if _, err := NewContext(context.Background(), WithTimeLayout("")); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestWithUTC(t *testing.T) {
+	local := time.Date(2001, 2, 3, 4, 5, 6, 0, time.FixedZone("UTC+2", 2*60*60))
+	clock := WithClock(func() time.Time { return local })
+
+	ctx, read := newFileContext(t, WithTimeKey("time"), WithUTC(), clock)
+
+	Info(ctx, "x")
+
+	if got := read()[0]["time"]; got != "2001-02-03T02:05:06Z" {
+		t.Errorf("unexpected time: %v", got)
+	}
+
+	ctx, read = newFileContext(t, WithTimeKey("time"), WithUTC(), WithTimeLayout(time.DateTime),
+		clock)
+
+	Info(ctx, "x")
+
+	if got := read()[0]["time"]; got != "2001-02-03 02:05:06" {
+		t.Errorf("unexpected time: %v", got)
+	}
+}
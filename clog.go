@@ -16,7 +16,20 @@ package clog
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -29,6 +42,33 @@ func (l Level) String() string {
 	return zapcore.Level(l).String()
 }
 
+// MarshalText marshals the level to its lowercase name (eg. "debug"). Together with
+// UnmarshalText it lets a Level be embedded in configuration structs decoded from JSON, YAML,
+// environment variables, etc.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// Set parses a level name with ParseLevel, so that *Level implements flag.Value:
+//
+//	level := clog.InfoLevel
+//	flag.Var(&level, "log-level", "logging level (debug, info, warn, error)")
+func (l *Level) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText parses a level name with ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+
+	return nil
+}
+
 const (
 	// DefaultLevel is the default logging level.
 	DefaultLevel = InfoLevel
@@ -42,6 +82,8 @@ const (
 	ErrorLevel = Level(zapcore.ErrorLevel)
 	// PanicLevel represents the PANIC level.
 	PanicLevel = Level(zapcore.PanicLevel)
+	// FatalLevel represents the FATAL level.
+	FatalLevel = Level(zapcore.FatalLevel)
 )
 
 const (
@@ -64,48 +106,287 @@ type Fields map[string]any
 type logKeyType string
 
 var (
-	loggerKey logKeyType = "logger"
-	levelKey  logKeyType = "level_key"
-	errorKey  logKeyType = "error_key"
+	loggerKey    logKeyType = "logger"
+	errorKey     logKeyType = "error_key"
+	closerKey    logKeyType = "closer"
+	explainKey   logKeyType = "explain"
+	recordKey    logKeyType = "record"
+	canonicalKey logKeyType = "canonical"
+	// contextFieldsKey holds the fields of the logging context, ie. those its logger was
+	// built with
+	contextFieldsKey logKeyType = "context_fields"
+	// baseKey holds the backend of the logging context without the context's fields, which
+	// it's rebuilt from when a field is overridden (see withZapFields)
+	baseKey        logKeyType = "base"
+	configKey      logKeyType = "config"
+	tailKey        logKeyType = "tail"
+	levelChangeKey logKeyType = "level_change"
+	samplerKey     logKeyType = "sampler"
+)
+
+// copiedKeys are the keys copied by CopyContext. The closer is left out since the copy
+// doesn't own the logger's resources.
+var copiedKeys = []logKeyType{
+	loggerKey, errorKey, explainKey, recordKey, canonicalKey, contextFieldsKey, baseKey,
+	configKey, tailKey, levelChangeKey, samplerKey,
+}
+
+const (
+	reasonBelowLevel = "below level"
+	reasonSkipped    = "skipped"
+	reasonSampled    = "sampled"
+	reasonDeduped    = "deduped"
 )
 
 // Option allows extending individual log records with additional structured data.
 type Option func(*options)
 
 type options struct {
-	err    error
-	fields map[string]any
+	err        error
+	errs       []error
+	fields     Fields
+	namespaces []namespace
+	skip       bool
+	force      bool
+	attempt    int
+}
+
+type namespace struct {
+	name   string
+	fields Fields
+}
+
+// set adds a field to the innermost namespace, if any, or to the top level.
+func (o *options) set(key string, value any) {
+	target := &o.fields
+	if n := len(o.namespaces); n > 0 {
+		target = &o.namespaces[n-1].fields
+	}
+
+	if *target == nil {
+		*target = make(Fields)
+	}
+
+	(*target)[key] = value
 }
 
 // WithError adds an error field to the log record.
+//
+// Repeated WithError options accumulate: the errors are combined with errors.Join and
+// emitted as a single error. A single error is emitted as-is.
 func WithError(err error) Option {
 	return func(o *options) {
-		o.err = err
+		switch {
+		case err == nil:
+		case o.err == nil:
+			o.err = err
+		default:
+			o.err = errors.Join(o.err, err)
+		}
 	}
 }
 
-// WithField adds a field to the log record.
-func WithField(key string, value any) Option {
+// WithErrors adds multiple errors to the log record. They are merged with the error given to
+// WithError, if any, and emitted as an array of messages under the error key (a single error
+// is emitted as with WithError).
+func WithErrors(errs ...error) Option {
 	return func(o *options) {
-		if o.fields == nil {
-			o.fields = make(Fields)
+		for _, err := range errs {
+			if err != nil {
+				o.errs = append(o.errs, err)
+			}
 		}
+	}
+}
 
-		o.fields[key] = value
+// WithField adds a field to the log record.
+func WithField(key string, value any) Option {
+	return func(o *options) {
+		o.set(key, value)
 	}
 }
 
 // WithFields adds multiple fields to the log record.
 func WithFields(fields Fields) Option {
 	return func(o *options) {
-		if o.fields == nil {
-			o.fields = make(Fields)
+		for k, v := range fields {
+			o.set(k, v)
 		}
+	}
+}
 
-		for k, v := range fields {
-			o.fields[k] = v
+// WithSkip skips the log record if skip is true, eg. to decide whether to log declaratively
+// when building options dynamically. Skipped records are dropped before their fields are
+// assembled; Panic and Fatal neither panic nor exit for them.
+func WithSkip(skip bool) Option {
+	return func(o *options) {
+		o.skip = o.skip || skip
+	}
+}
+
+// WithForce writes the log record even if its level is disabled on the logging context, eg.
+// an audit record that must be written whatever the configured level.
+func WithForce() Option {
+	return func(o *options) {
+		o.force = true
+	}
+}
+
+// WithNamespace nests the fields added by the options that follow it (in the order the
+// options are given) under name, eg. {"http": {"method": "GET"}}. Fields added by preceding
+// options stay at the top level. Namespaces nest: a second WithNamespace opens a namespace
+// within the first one. Errors are always logged at the top level.
+//
+// Namespaced fields are emitted after the top-level ones. This is only meaningful with JSON
+// encoding.
+func WithNamespace(name string) Option {
+	return func(o *options) {
+		o.namespaces = append(o.namespaces, namespace{name: name})
+	}
+}
+
+// WithLazy adds a field whose value is computed by fn only when the log record is actually
+// written, so expensive values cost nothing when the level is disabled or the record is
+// otherwise dropped.
+func WithLazy(key string, fn func() any) Option {
+	return WithField(key, lazyValue(fn))
+}
+
+// lazyValue defers computing a field's value until it is encoded.
+type lazyValue func() any
+
+func (v lazyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v())
+}
+
+// WithSecretMetadata adds metadata about a secret to the log record: its keyID ("key_id"),
+// version ("key_version") and a short SHA-256 fingerprint of value ("key_fingerprint").
+// The value itself is never logged.
+//
+// The fingerprint is an unsalted 64-bit prefix of the hash, so it can be brute-forced for
+// low-entropy secrets such as passwords or PINs; only use it for high-entropy keys.
+func WithSecretMetadata(keyID string, version int, value []byte) Option {
+	sum := sha256.Sum256(value)
+
+	return WithFields(Fields{
+		"key_id":          keyID,
+		"key_version":     version,
+		"key_fingerprint": "sha256:" + hex.EncodeToString(sum[:8]),
+	})
+}
+
+// WithBucketedField adds a field with value under key plus a "<key>_bucket" field with the
+// upper boundary of the bucket that value falls in (the smallest of buckets that is greater
+// than or equal to value, or "+Inf"), to keep the cardinality of log-derived metrics low.
+func WithBucketedField(key string, value float64, buckets []float64) Option {
+	bucket := math.Inf(1)
+
+	for _, b := range buckets {
+		if value <= b && b < bucket {
+			bucket = b
 		}
 	}
+
+	return WithFields(Fields{
+		key:             value,
+		key + "_bucket": strconv.FormatFloat(bucket, 'g', -1, 64),
+	})
+}
+
+// TTLKey is the key that has as value the retention period of a log record (see WithTTL).
+const TTLKey = "ttl_seconds"
+
+// WithTTL adds a "ttl_seconds" field with how long the log record should be retained, in
+// whole seconds, so that log stores with retention tiers can expire it accordingly.
+func WithTTL(d time.Duration) Option {
+	return WithField(TTLKey, int64(d/time.Second))
+}
+
+// WithCount adds a counter increment n under key. It's emitted as an integer field, so hooks
+// (see WithHooks) can turn it into a metric, eg. by adding field.Integer to a counter named
+// after field.Key when field.Type is zapcore.Int64Type.
+func WithCount(key string, n int64) Option {
+	return WithField(key, n)
+}
+
+// WithRawJSON adds data, which is already JSON encoded, under key as-is (eg. as a nested
+// object) rather than as a string. If data isn't valid JSON then it's added as a string, and
+// key under "field_error" (see WithFieldValidation).
+func WithRawJSON(key string, data []byte) Option {
+	if !json.Valid(data) {
+		return WithFields(Fields{key: string(data), FieldErrorKey: key})
+	}
+
+	return WithField(key, rawJSON(data))
+}
+
+// rawJSON is an already JSON encoded value. Unlike json.RawMessage, which zap logs as a
+// string, it is encoded through reflection and thus as JSON.
+type rawJSON []byte
+
+func (v rawJSON) MarshalJSON() ([]byte, error) {
+	return v, nil
+}
+
+// WithDurationMillis adds d under key as a number of milliseconds (with fractions).
+func WithDurationMillis(key string, d time.Duration) Option {
+	return WithDurationUnit(key, d, time.Millisecond)
+}
+
+// WithDurationUnit adds d under key as a number of units (with fractions), eg. 1.5 for 1.5s
+// with time.Second as unit.
+func WithDurationUnit(key string, d, unit time.Duration) Option {
+	return WithField(key, float64(d)/float64(unit))
+}
+
+// WithMap adds m under key as a nested object, with its keys sorted. It's cheaper than
+// WithField(key, m), which encodes m through reflection.
+func WithMap(key string, m map[string]string) Option {
+	return WithField(key, stringMap(m))
+}
+
+// stringMap marshals a map of strings as an object with sorted keys.
+type stringMap map[string]string
+
+func (m stringMap) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		enc.AddString(k, m[k])
+	}
+
+	return nil
+}
+
+// WithStrings adds vals under key as an array of strings. Unlike WithField, it encodes vals
+// without reflection even if they have a named slice type (eg. type Tags []string).
+func WithStrings(key string, vals []string) Option {
+	return WithField(key, stringArray(vals))
+}
+
+// WithInts adds vals under key as an array of integers, without reflection like WithStrings.
+func WithInts(key string, vals []int) Option {
+	return WithField(key, intArray(vals))
+}
+
+// stringArray marshals a slice of strings as an array.
+type stringArray []string
+
+func (a stringArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for i := range a {
+		enc.AppendString(a[i])
+	}
+
+	return nil
+}
+
+// intArray marshals a slice of integers as an array.
+type intArray []int
+
+func (a intArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for i := range a {
+		enc.AppendInt(a[i])
+	}
+
+	return nil
 }
 
 // ContextOption allows customization of a few aspects of a logging context.
@@ -120,6 +401,64 @@ type contextOptions struct {
 	timeKey    string
 	errorKey   string
 	hooks      []func(zapcore.Entry, []zapcore.Field)
+	rotation   *rotationConfig
+	splitLevel *Level
+	explain    zapcore.WriteSyncer
+	maxBytes   int
+	maxFields  int
+	fields     []zap.Field
+	record     recordConfig
+	clock      func() time.Time
+
+	deadlineThreshold float64
+	levelAliases      []string
+	colorLevels       bool
+	encodeLevel       zapcore.LevelEncoder
+	encodeTime        zapcore.TimeEncoder
+	encoder           zapcore.Encoder
+	enabler           zapcore.LevelEnabler
+	dedupe            bool
+	caller            bool
+	callerSkip        int
+	sampling          func(zapcore.Entry) zapcore.SamplingDecision
+	syncOnError       bool
+	fieldPrefix       string
+	teeCores          []zapcore.Core
+	tailCapacity      int
+	encodeDuration    zapcore.DurationEncoder
+	sortFields        bool
+	validateFields    bool
+	levelCallbacks    []func(old, new Level)
+	levelSampling     *levelSampler
+	backend           Backend
+	utc               bool
+	fullEncoderConfig *zapcore.EncoderConfig
+	consoleSeparator  string
+	lineEnding        string
+	outputFallback    bool
+	dedupWindow       time.Duration
+	writeErrorHandler func(err error, entry zapcore.Entry)
+	mutators          []func(*zapcore.Entry, []zapcore.Field) []zapcore.Field
+	// setupLogs are invoked with the new logging context once it is built, to report
+	// problems found while applying the options
+	setupLogs []func(context.Context)
+	// errs are the invalid options, which make NewContext fail
+	errs []error
+}
+
+// recordConfig holds the logging context's configuration used while assembling records.
+type recordConfig struct {
+	errorChain     bool
+	errorFormatter func(error) any
+	caller         bool
+	stackSkip      int
+	traceSampled   func(context.Context) bool
+	extractors     []func(context.Context) Fields
+	defaults       Fields
+	deadline       *deadlineBudget
+	escalation     map[int]Level
+
+	deprecations *sync.Map
 }
 
 // WithLevel lets the logging context's Level to level. InfoLevel is the default Level.
@@ -129,6 +468,43 @@ func WithLevel(level Level) ContextOption {
 	}
 }
 
+// WithLevelEnabler additionally filters records with enabler, eg. to enable DebugLevel only
+// at certain times or for sampled traces. A record is written only if it's enabled by both
+// the logging context's Level (see SetLevel) and enabler, which is consulted for every record
+// and must be safe for concurrent use.
+func WithLevelEnabler(enabler zapcore.LevelEnabler) ContextOption {
+	return func(o *contextOptions) {
+		o.enabler = enabler
+	}
+}
+
+// WithLevelFromEnv sets the logging context's Level from the environment variable varName
+// (eg. LOG_LEVEL=debug), parsed with ParseLevel. If the variable is unset the level is left
+// as is; if it is invalid the level is left as is as well and a warning is logged.
+func WithLevelFromEnv(varName string) ContextOption {
+	return func(o *contextOptions) {
+		value, ok := os.LookupEnv(varName)
+		if !ok || value == "" {
+			return
+		}
+
+		level, err := ParseLevel(value)
+		if err != nil {
+			o.setupLogs = append(o.setupLogs, func(ctx context.Context) {
+				Warn(ctx, "ignoring invalid log level from the environment",
+					WithField("variable", varName),
+					WithField("value", value),
+					WithError(err),
+				)
+			})
+
+			return
+		}
+
+		o.level = level
+	}
+}
+
 // WithJSONEncoding sets the logging format to JSON. 'Console' format is the default format.
 func WithJSONEncoding() ContextOption {
 	return func(o *contextOptions) {
@@ -143,6 +519,67 @@ func WithConsoleEncoding() ContextOption {
 	}
 }
 
+// WithConsoleSeparator separates the elements of console records (time, level, message and
+// fields) with sep rather than a tab. It has no effect with JSON encoding.
+func WithConsoleSeparator(sep string) ContextOption {
+	return func(o *contextOptions) {
+		o.consoleSeparator = sep
+	}
+}
+
+// WithLineEnding terminates records with ending rather than "\n", eg. "\r\n" for tools
+// expecting CRLF line endings. An empty ending makes NewContext fail.
+func WithLineEnding(ending string) ContextOption {
+	return func(o *contextOptions) {
+		if ending == "" {
+			o.errs = append(o.errs, errors.New("empty line ending"))
+
+			return
+		}
+
+		o.lineEnding = ending
+	}
+}
+
+// WithEncoderConfig makes the built-in JSON and console encoders use config as-is, for full
+// control over the layout (eg. config.LineEnding or config.ConsoleSeparator). The options
+// assembling the encoder configuration are then ignored: the level, message and time keys
+// (WithLevelKey, WithNoTimeKey, etc.), the time, level and duration encoders (WithTimeLayout,
+// WithUTC, WithColorLevels, etc.) and the caller key of WithCaller. WithEncoder takes
+// precedence over it.
+func WithEncoderConfig(config zapcore.EncoderConfig) ContextOption {
+	return func(o *contextOptions) {
+		o.fullEncoderConfig = &config
+	}
+}
+
+// WithEncoder makes the logging context encode records with enc, eg. for a proprietary
+// format. WithJSONEncoding, WithConsoleEncoding and WithColorLevels are ignored when a
+// custom encoder is given, as are the level, message and time key options (enc decides how
+// entries are laid out).
+func WithEncoder(enc zapcore.Encoder) ContextOption {
+	return func(o *contextOptions) {
+		o.encoder = enc
+	}
+}
+
+// WithDurationEncoder sets how time.Duration values are encoded (as nanoseconds by default),
+// eg. zapcore.StringDurationEncoder for "1.5s" or zapcore.MillisDurationEncoder.
+func WithDurationEncoder(enc zapcore.DurationEncoder) ContextOption {
+	return func(o *contextOptions) {
+		o.encodeDuration = enc
+	}
+}
+
+// WithColorLevels colorizes the levels (eg. red ERROR, yellow WARN) with console encoding; it
+// is a no-op with JSON encoding. The colors are ANSI escape sequences written regardless of
+// the output, so only enable it for interactive terminal sessions.
+func WithColorLevels() ContextOption {
+	return func(o *contextOptions) {
+		o.colorLevels = true
+	}
+}
+
 // OutputToStdout redirects logging output to os.Stdout (default is os.Stderr).
 func OutputToStdout() ContextOption {
 	return func(o *contextOptions) {
@@ -150,6 +587,85 @@ func OutputToStdout() ContextOption {
 	}
 }
 
+// WithOutputPath redirects logging output to path, which is either a file path or a URL
+// ("stdout", "stderr" and "file:///var/log/app.log" are always supported; other schemes can be
+// added with RegisterSink). Close releases the sink.
+func WithOutputPath(path string) ContextOption {
+	return func(o *contextOptions) {
+		o.outputPath = path
+	}
+}
+
+// WithOutputFallback makes the logging context log to stderr if its output (see
+// WithOutputPath and WithRotatingFile) can't be opened, eg. because a log volume isn't
+// mounted, rather than failing. The failure is reported with a warning.
+func WithOutputFallback() ContextOption {
+	return func(o *contextOptions) {
+		o.outputFallback = true
+	}
+}
+
+// WithStdoutBelow splits logging output by level: records below level are written to
+// os.Stdout and the rest to os.Stderr.
+//
+// It conflicts with the other output options (OutputToStdout, WithOutputPath,
+// WithRotatingFile); combining them makes NewContext return an error.
+func WithStdoutBelow(level Level) ContextOption {
+	return func(o *contextOptions) {
+		o.splitLevel = &level
+	}
+}
+
+// WithLevelSplitOutput writes Debug and Info records to os.Stdout and Warn and above to
+// os.Stderr. It is shorthand for WithStdoutBelow(WarnLevel).
+func WithLevelSplitOutput() ContextOption {
+	return WithStdoutBelow(WarnLevel)
+}
+
+// WithRotatingFile redirects logging output to the file at path, rotating it once it grows
+// beyond maxSizeMB megabytes (100 if zero). Rotated files are renamed with a timestamp suffix;
+// at most maxBackups of them are retained and those older than maxAgeDays are removed (zero
+// disables the respective limit).
+func WithRotatingFile(path string, maxSizeMB, maxBackups, maxAgeDays int) ContextOption {
+	return func(o *contextOptions) {
+		o.rotation = &rotationConfig{
+			path:       path,
+			maxSizeMB:  maxSizeMB,
+			maxBackups: maxBackups,
+			maxAgeDays: maxAgeDays,
+		}
+	}
+}
+
+// WithExplain is a diagnostic aid that writes a short reason to w for every log record that
+// is suppressed: because it is below the context's level, skipped (see WithSkip), sampled (see
+// WithSamplingHook and WithLevelSampling) or deduplicated (see WithDedup). It is disabled by
+// default.
+func WithExplain(w io.Writer) ContextOption {
+	return func(o *contextOptions) {
+		o.explain = zapcore.Lock(zapcore.AddSync(w))
+	}
+}
+
+// WithMaxFieldBytes truncates string, byte, error and fmt.Stringer field values longer than n
+// bytes, appending a "…(truncated)" marker. It applies to both context fields and per-record
+// fields. Other values (eg. structs, maps and slices logged with WithField) are not truncated.
+func WithMaxFieldBytes(n int) ContextOption {
+	return func(o *contextOptions) {
+		o.maxBytes = n
+	}
+}
+
+// WithMaxFields keeps at most n fields per record and records the number of dropped fields
+// under "fields_dropped". Fields are kept in order: context fields in the order they were
+// added, then per-record fields sorted by key. Error fields are never dropped and don't count
+// towards n.
+func WithMaxFields(n int) ContextOption {
+	return func(o *contextOptions) {
+		o.maxFields = n
+	}
+}
+
 // WithLevelKey allows switching away from the DefaultLevelKey.
 func WithLevelKey(key string) ContextOption {
 	return func(o *contextOptions) {
@@ -157,6 +673,24 @@ func WithLevelKey(key string) ContextOption {
 	}
 }
 
+// WithNoLevelKey omits the level from log messages, eg. for sinks that derive the severity
+// elsewhere. Records are still filtered by level.
+func WithNoLevelKey() ContextOption {
+	return func(o *contextOptions) {
+		o.levelKey = ""
+	}
+}
+
+// WithDuplicateLevelKey additionally writes the level under key (eg. "level" alongside
+// "severity"), for downstream systems that disagree on the level field name. The duplicate is
+// written as a field of the record, encoded like the level (eg. "WARNING" with
+// WithGCPSeverity). It can be given several times.
+func WithDuplicateLevelKey(key string) ContextOption {
+	return func(o *contextOptions) {
+		o.levelAliases = append(o.levelAliases, key)
+	}
+}
+
 // WithMessageKey allows switching away from the DefaultMessageKey.
 func WithMessageKey(key string) ContextOption {
 	return func(o *contextOptions) {
@@ -164,6 +698,14 @@ func WithMessageKey(key string) ContextOption {
 	}
 }
 
+// WithNoMessageKey omits the message from log messages, for records that are fully described
+// by their fields (log them with an empty message).
+func WithNoMessageKey() ContextOption {
+	return func(o *contextOptions) {
+		o.msgKey = ""
+	}
+}
+
 // WithTimeKey allows switching away from the DefaultTimeKey.
 func WithTimeKey(key string) ContextOption {
 	return func(o *contextOptions) {
@@ -178,6 +720,58 @@ func WithNoTimeKey() ContextOption {
 	}
 }
 
+// WithTimeLayout formats timestamps with layout (see time.Layout) rather than as RFC3339, eg.
+// "2006-01-02 15:04:05.000". It has no effect with WithNoTimeKey. An empty layout makes
+// NewContext fail.
+func WithTimeLayout(layout string) ContextOption {
+	return func(o *contextOptions) {
+		if layout == "" {
+			o.errs = append(o.errs, errors.New("empty time layout"))
+
+			return
+		}
+
+		o.encodeTime = zapcore.TimeEncoderOfLayout(layout)
+	}
+}
+
+// WithUTC converts timestamps to UTC before formatting them, whatever their format (eg. with
+// WithTimeLayout), so that records from servers in different time zones line up.
+func WithUTC() ContextOption {
+	return func(o *contextOptions) {
+		o.utc = true
+	}
+}
+
+// WithCaller annotates records with the location (file:line) of the call to the logging
+// function (Info, Log, etc.) under "caller". Records logged by helpers such as LogRetry carry
+// the location of the call to the helper instead.
+func WithCaller() ContextOption {
+	return func(o *contextOptions) {
+		o.caller = true
+	}
+}
+
+// WithCallerSkip skips n additional stack frames when annotating records with the caller, so
+// that helper functions wrapping clog's report their own caller. It has no effect without
+// WithCaller.
+func WithCallerSkip(n int) ContextOption {
+	return func(o *contextOptions) {
+		o.callerSkip += n
+	}
+}
+
+// skipCallers returns a logging context derived from ctx that skips n additional stack frames
+// when annotating records with the caller (see WithCaller), for clog's own helpers.
+func skipCallers(ctx context.Context, n int) context.Context {
+	b, ok := zapBackendOf(ctx)
+	if rc, _ := ctx.Value(recordKey).(*recordConfig); !ok || rc == nil || !rc.caller {
+		return ctx
+	}
+
+	return context.WithValue(ctx, loggerKey, b.withOptions(zap.AddCallerSkip(n)))
+}
+
 // WithErrorKey allows switching away from the DefaultErrorKey.
 func WithErrorKey(key string) ContextOption {
 	return func(o *contextOptions) {
@@ -206,6 +800,19 @@ func ParseLevel(level string) (Level, error) {
 	return Level(l), nil
 }
 
+// MustParseLevel is like ParseLevel but panics if level is invalid. It simplifies the
+// initialization of package variables:
+//
+//	var level = clog.MustParseLevel("debug")
+func MustParseLevel(level string) Level {
+	l, err := ParseLevel(level)
+	if err != nil {
+		panic(fmt.Sprintf("clog: MustParseLevel(%q): %v", level, err))
+	}
+
+	return l
+}
+
 // Context returns a new contextual logging context.
 //
 // The returned context is a child of parent unless parent is nil; in that case the returned
@@ -213,7 +820,21 @@ func ParseLevel(level string) (Level, error) {
 //
 // It is important to obtain a logging context with this function first before invoking any
 // of the rest. Not doing so renders all other functions as no-ops.
+//
+// Context panics if the logger cannot be built (eg. the output path cannot be opened); use
+// NewContext to handle such errors instead.
 func Context(parent context.Context, opts ...ContextOption) context.Context {
+	ctx, err := NewContext(parent, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return ctx
+}
+
+// NewContext is like Context but returns an error instead of panicking if the logger cannot
+// be built.
+func NewContext(parent context.Context, opts ...ContextOption) (context.Context, error) {
 	if parent == nil {
 		parent = context.Background()
 	}
@@ -232,242 +853,857 @@ func Context(parent context.Context, opts ...ContextOption) context.Context {
 		opts[i](o)
 	}
 
-	level := zap.NewAtomicLevelAt(zapcore.Level(o.level))
-
-	zapConfig := zap.Config{
-		Level:             level,
-		DisableCaller:     true,
-		DisableStacktrace: true,
-		Encoding:          o.encoding,
-		EncoderConfig: zapcore.EncoderConfig{
-			MessageKey:  o.msgKey,
-			LevelKey:    o.levelKey,
-			TimeKey:     o.timeKey,
-			EncodeTime:  zapcore.RFC3339TimeEncoder,
-			EncodeLevel: zapcore.CapitalLevelEncoder,
-		},
-		OutputPaths: []string{o.outputPath},
+	if err := errors.Join(o.errs...); err != nil {
+		return nil, err
 	}
 
-	logger := zap.Must(zapConfig.Build())
+	config := newConfig(o)
 
-	if len(o.hooks) > 0 {
-		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
-			return &hooksLogger{
-				Core:  core,
-				hooks: o.hooks,
-			}
-		}))
+	o.record.extractors = append(registeredGlobalFieldHooks(), o.record.extractors...)
+	o.record.deprecations = new(sync.Map)
+
+	now := o.clock
+	if now == nil {
+		now = time.Now
 	}
 
-	return context.WithValue(
-		context.WithValue(
-			context.WithValue(parent, loggerKey, logger),
-			levelKey,
-			&level,
-		),
-		errorKey,
-		o.errorKey,
+	o.record.deadline = newDeadlineBudget(parent, o.deadlineThreshold, now)
+
+	var (
+		backend = o.backend
+		tail    *RingBufferCore
+		closer  func() error
 	)
-}
 
-// CopyContext copies the logging context from 'from' into a new context derived from 'to'.
-//
-// This is a no-op if 'from' is not a logging context ('to' is returned as-is).
-func CopyContext(to, from context.Context) context.Context {
-	logger, ok := from.Value(loggerKey).(*zap.Logger)
-	if !ok {
-		return to
+	if backend == nil {
+		zb, t, c, err := newZapBackend(o)
+		if err != nil {
+			return nil, err
+		}
+
+		backend, tail, closer = zb, t, c
+	} else {
+		backend.SetLevel(o.level)
+		closer = backend.Sync
+		config = nil
 	}
 
-	return context.WithValue(to, loggerKey, logger)
-}
+	base := backend
 
-// ContextWithField returns a new logging context derived from parent and including
-// the given key and value.
-//
-// If parent is not a logging context then parent is returned as-is.
-func ContextWithField(parent context.Context, k string, v any) context.Context {
-	logger, ok := parent.Value(loggerKey).(*zap.Logger)
-	if !ok {
-		return parent
+	if len(o.fields) > 0 {
+		backend = withZap(backend, o.fields)
 	}
 
-	logger = logger.With(zap.Any(k, v))
+	ctx := context.WithValue(parent, loggerKey, backend)
+	ctx = context.WithValue(ctx, errorKey, o.errorKey)
+	ctx = context.WithValue(ctx, closerKey, closer)
+	ctx = context.WithValue(ctx, recordKey, &o.record)
+	ctx = context.WithValue(ctx, contextFieldsKey, slices.Clip(o.fields))
+	ctx = context.WithValue(ctx, baseKey, base)
+	ctx = context.WithValue(ctx, configKey, config)
 
-	return context.WithValue(parent, loggerKey, logger)
-}
+	if tail != nil {
+		ctx = context.WithValue(ctx, tailKey, tail)
+	}
 
-// ContextWithFields returns a new logging context derived from parent and including
-// the given keys and values.
-//
-// If parent is not a logging context then parent is returned as-is.
-func ContextWithFields(parent context.Context, fields Fields) context.Context {
-	logger, ok := parent.Value(loggerKey).(*zap.Logger)
-	if !ok {
-		return parent
+	if len(o.levelCallbacks) > 0 {
+		ctx = context.WithValue(ctx, levelChangeKey, &levelNotifier{callbacks: o.levelCallbacks})
 	}
 
-	zf := make([]zap.Field, 0, len(fields))
+	if o.levelSampling != nil {
+		ctx = context.WithValue(ctx, samplerKey, o.levelSampling)
+	}
 
-	for k, v := range fields {
-		zf = append(zf, zap.Any(k, v))
+	if o.explain != nil {
+		ctx = context.WithValue(ctx, explainKey, o.explain)
 	}
 
-	logger = logger.With(zf...)
+	for _, log := range o.setupLogs {
+		log(ctx)
+	}
 
-	return context.WithValue(parent, loggerKey, logger)
+	return ctx, nil
 }
 
-// SetLevel adjusts the logging level on the given logging context.
+// newZapBackend builds the default backend, writing with zap as configured by o, along with
+// the in-memory tail of the records (if enabled) and the function releasing its resources.
+func newZapBackend(o *contextOptions) (*zapBackend, *RingBufferCore, func() error, error) {
+	level := zap.NewAtomicLevelAt(zapcore.Level(o.level))
+
+	var enabler zapcore.LevelEnabler = level
+	if o.enabler != nil {
+		enabler = zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return level.Enabled(l) && o.enabler.Enabled(l)
+		})
+	}
+
+	core, closer, err := newCore(o, enabler)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to build logger: %w", err)
+	}
+
+	var tail *RingBufferCore
+
+	if o.tailCapacity > 0 {
+		// the encoder is valid, newCore built one already
+		encoder, _ := newEncoder(o)
+		tail = newRingBufferCore(encoder, enabler, o.tailCapacity)
+		core = zapcore.NewTee(core, tail)
+	}
+
+	if len(o.teeCores) > 0 {
+		core = zapcore.NewTee(append([]zapcore.Core{core}, o.teeCores...)...)
+	}
+
+	// zap's internal errors are discarded, as they were when built from a zap.Config
+	logger := zap.New(core, zap.ErrorOutput(zapcore.AddSync(io.Discard)))
+
+	if o.writeErrorHandler != nil {
+		// wrapped first, so that it sees the errors of the records written by any other wrapper
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &writeErrorCore{
+				Core:     core,
+				handle:   o.writeErrorHandler,
+				handling: new(atomic.Bool),
+			}
+		}))
+	}
+
+	if o.clock != nil {
+		logger = logger.WithOptions(zap.WithClock(funcClock(o.clock)))
+	}
+
+	if o.caller {
+		logger = logger.WithOptions(zap.AddCaller(), zap.AddCallerSkip(2+o.callerSkip))
+		o.record.caller = true
+	}
+
+	if o.syncOnError {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &syncOnErrorCore{Core: core}
+		}))
+	}
+
+	if len(o.levelAliases) > 0 {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return newLevelAliasCore(core, o.levelAliases, o.encoderConfig().EncodeLevel)
+		}))
+	}
+
+	if o.fieldPrefix != "" {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &prefixCore{
+				Core:   core,
+				prefix: o.fieldPrefix,
+			}
+		}))
+	}
+
+	if len(o.hooks) > 0 {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &hooksLogger{
+				Core:  core,
+				hooks: o.hooks,
+			}
+		}))
+	}
+
+	if o.dedupe && len(o.hooks) == 0 {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &rewriteCore{Core: core, rewrite: dedupeFields}
+		}))
+	}
+
+	if o.sortFields {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &rewriteCore{Core: core, rewrite: sortFields}
+		}))
+	}
+
+	if o.validateFields {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &rewriteCore{Core: core, rewrite: validateFields}
+		}))
+	}
+
+	if o.maxBytes > 0 || o.maxFields > 0 {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &limitsCore{
+				Core:          core,
+				maxFieldBytes: o.maxBytes,
+				maxFields:     o.maxFields,
+			}
+		}))
+	}
+
+	if len(o.mutators) > 0 {
+		// wrapped after the cores rewriting fields, so that they apply to the mutated records
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &mutatorCore{Core: core, mutators: o.mutators}
+		}))
+	}
+
+	if o.dedupWindow > 0 {
+		state := newDedupState(o.dedupWindow)
+
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &dedupCore{Core: core, state: state, explain: o.explain}
+		}))
+
+		// the held records are written before the output is closed
+		closeSink := closer
+		closer = func() error {
+			return errors.Join(state.flush(), closeSink())
+		}
+	}
+
+	if o.sampling != nil {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &samplingCore{
+				Core:    core,
+				decide:  o.sampling,
+				explain: o.explain,
+			}
+		}))
+	}
+
+	if o.levelSampling != nil {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &samplingCore{
+				Core:    core,
+				decide:  o.levelSampling.decide,
+				explain: o.explain,
+			}
+		}))
+	}
+
+	return &zapBackend{logger: logger, level: &level}, tail, closer, nil
+}
+
+func newCore(
+	o *contextOptions, level zapcore.LevelEnabler,
+) (zapcore.Core, func() error, error) {
+	encoder, err := newEncoder(o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if o.splitLevel != nil {
+		if o.rotation != nil || o.outputPath != "stderr" {
+			return nil, nil, errors.New("split output conflicts with other output options")
+		}
+
+		return &splitCore{
+			below: zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), level),
+			above: zapcore.NewCore(encoder.Clone(), zapcore.Lock(os.Stderr), level),
+			split: zapcore.Level(*o.splitLevel),
+		}, nopCloser, nil
+	}
+
+	sink, closer, err := newSink(o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return zapcore.NewCore(encoder, sink, level), closer, nil
+}
+
+func newEncoder(o *contextOptions) (zapcore.Encoder, error) {
+	switch {
+	case o.encoder != nil:
+		return o.encoder.Clone(), nil
+	case o.encoding == "json":
+		return zapcore.NewJSONEncoder(o.encoderConfig()), nil
+	case o.encoding == "console":
+		return zapcore.NewConsoleEncoder(o.encoderConfig()), nil
+	default:
+		return nil, fmt.Errorf("invalid encoding: %q", o.encoding)
+	}
+}
+
+// encoderConfig returns the configuration of the built-in encoders.
+func (o *contextOptions) encoderConfig() zapcore.EncoderConfig {
+	if o.fullEncoderConfig != nil {
+		return *o.fullEncoderConfig
+	}
+
+	config := zapcore.EncoderConfig{
+		MessageKey:  o.msgKey,
+		LevelKey:    o.levelKey,
+		TimeKey:     o.timeKey,
+		EncodeTime:  zapcore.RFC3339TimeEncoder,
+		EncodeLevel: zapcore.CapitalLevelEncoder,
+	}
+
+	if o.caller {
+		config.CallerKey = CallerKey
+		config.EncodeCaller = zapcore.ShortCallerEncoder
+	}
+
+	if o.encodeTime != nil {
+		config.EncodeTime = o.encodeTime
+	}
+
+	if o.utc {
+		encodeTime := config.EncodeTime
+		config.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			encodeTime(t.UTC(), enc)
+		}
+	}
+
+	if o.encodeDuration != nil {
+		config.EncodeDuration = o.encodeDuration
+	}
+
+	if o.encoding == "console" {
+		config.ConsoleSeparator = o.consoleSeparator
+	}
+
+	if o.lineEnding != "" {
+		config.LineEnding = o.lineEnding
+	}
+
+	switch {
+	case o.encodeLevel != nil:
+		config.EncodeLevel = o.encodeLevel
+	case o.colorLevels && o.encoding == "console":
+		config.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+
+	return config
+}
+
+func newSink(o *contextOptions) (zapcore.WriteSyncer, func() error, error) {
+	sink, closer, err := openSink(o)
+	if err == nil || !o.outputFallback {
+		return sink, closer, err
+	}
+
+	o.setupLogs = append(o.setupLogs, func(ctx context.Context) {
+		Warn(ctx, "failed to open the output, logging to stderr instead", WithError(err))
+	})
+
+	return zapcore.Lock(os.Stderr), nopCloser, nil
+}
+
+// openSink opens the output configured by o.
+func openSink(o *contextOptions) (zapcore.WriteSyncer, func() error, error) {
+	if o.rotation != nil {
+		f, err := newRotatingFile(o.rotation)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return f, f.Close, nil
+	}
+
+	sink, cleanup, err := zap.Open(o.outputPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open output path: %w", err)
+	}
+
+	return sink, func() error {
+		cleanup()
+
+		return nil
+	}, nil
+}
+
+func nopCloser() error {
+	return nil
+}
+
+// Close releases the resources (eg. files opened with WithRotatingFile) held by the logging
+// context. The logging context, and any context derived from it, must not be used for logging
+// afterwards.
+//
+// If ctx is not a logging context then this is a no-op.
+func Close(ctx context.Context) error {
+	closer, ok := ctx.Value(closerKey).(func() error)
+	if !ok {
+		return nil
+	}
+
+	return closer()
+}
+
+// IsLoggingContext reports whether ctx is a logging context (see Context), ie. whether the
+// other functions log rather than being no-ops. It's a single context value lookup.
+func IsLoggingContext(ctx context.Context) bool {
+	_, ok := backendOf(ctx)
+
+	return ok
+}
+
+// CopyContext copies the logging context from 'from' into a new context derived from 'to'.
+// The copy shares the logger, its level (so SetLevel affects both) and its configuration
+// (eg. the error key) with 'from'.
+//
+// This is a no-op if 'from' is not a logging context ('to' is returned as-is).
+func CopyContext(to, from context.Context) context.Context {
+	if _, ok := backendOf(from); !ok {
+		return to
+	}
+
+	for _, key := range copiedKeys {
+		if v := from.Value(key); v != nil {
+			to = context.WithValue(to, key, v)
+		}
+	}
+
+	return to
+}
+
+// ContextWithField returns a new logging context derived from parent and including
+// the given key and value. The value overrides the one of a field with the same key added
+// earlier (including with WithHostInfo, WithVersion, etc.), and the key is written once.
+//
+// If parent is not a logging context then parent is returned as-is.
+func ContextWithField(parent context.Context, k string, v any) context.Context {
+	b, ok := backendOf(parent)
+	if !ok {
+		return parent
+	}
+
+	accumulate(parent, Fields{k: v})
+
+	return withZapFields(parent, b, zap.Any(k, v))
+}
+
+// ContextWithFields returns a new logging context derived from parent and including
+// the given keys and values, which override earlier fields like with ContextWithField.
+//
+// If parent is not a logging context then parent is returned as-is.
+func ContextWithFields(parent context.Context, fields Fields) context.Context {
+	b, ok := backendOf(parent)
+	if !ok {
+		return parent
+	}
+
+	accumulate(parent, fields)
+
+	return withZapFields(parent, b, zapFields(fields)...)
+}
+
+// ContextWithZapFields is like ContextWithFields but takes fields built with zap, which are
+// added as-is rather than through zap.Any, eg. to avoid the reflection when attaching many
+// fields to a context in a hot path.
+//
+// If parent is not a logging context then parent is returned as-is.
+func ContextWithZapFields(parent context.Context, fields ...zap.Field) context.Context {
+	b, ok := backendOf(parent)
+	if !ok {
+		return parent
+	}
+
+	accumulateZapFields(parent, fields)
+
+	return withZapFields(parent, b, fields...)
+}
+
+// ContextWithFieldOK is like ContextWithField but also reports whether parent is a logging
+// context, ie. whether the field was added. It helps catching contexts that weren't obtained
+// with Context first.
+func ContextWithFieldOK(parent context.Context, k string, v any) (context.Context, bool) {
+	if _, ok := backendOf(parent); !ok {
+		return parent, false
+	}
+
+	return ContextWithField(parent, k, v), true
+}
+
+// ContextWithFieldsOK is like ContextWithFields but also reports whether parent is a logging
+// context, ie. whether the fields were added.
+func ContextWithFieldsOK(parent context.Context, fields Fields) (context.Context, bool) {
+	if _, ok := backendOf(parent); !ok {
+		return parent, false
+	}
+
+	return ContextWithFields(parent, fields), true
+}
+
+// withZapFields returns a new logging context derived from parent whose backend includes the
+// given fields, and records them as part of the context's fields. A field overrides the
+// context field with the same key, if any: the backend is then rebuilt with the merged
+// fields, so that each key is written once.
+func withZapFields(parent context.Context, b Backend, fields ...zap.Field) context.Context {
+	inherited, _ := parent.Value(contextFieldsKey).([]zap.Field)
+	merged, replaced := mergeFields(inherited, fields)
+	base, rebuild := parent.Value(baseKey).(Backend)
+
+	switch {
+	case replaced && rebuild:
+		b = withZap(base, merged)
+	case replaced:
+		// the backend can't be rebuilt (see Combine), the overridden fields stay
+		merged = append(slices.Clip(inherited), fields...)
+		b = withZap(b, fields)
+	default:
+		b = withZap(b, fields)
+	}
+
+	ctx := context.WithValue(parent, loggerKey, b)
+
+	return context.WithValue(ctx, contextFieldsKey, merged)
+}
+
+// mergeFields returns inherited with fields added, and whether any of them replaced an
+// inherited field with the same key (in place, the others are appended). Fields are always
+// appended if there's a namespace among them, since keys are then scoped.
+func mergeFields(inherited, fields []zap.Field) ([]zap.Field, bool) {
+	isNamespace := func(f zap.Field) bool {
+		return f.Type == zapcore.NamespaceType
+	}
+
+	if slices.ContainsFunc(inherited, isNamespace) || slices.ContainsFunc(fields, isNamespace) {
+		return append(slices.Clip(inherited), fields...), false
+	}
+
+	var (
+		merged   = slices.Clip(inherited)
+		replaced bool
+	)
+
+	for _, f := range fields {
+		i := slices.IndexFunc(merged, func(m zap.Field) bool { return m.Key == f.Key })
+		if i < 0 {
+			merged = append(merged, f)
+
+			continue
+		}
+
+		if !replaced {
+			merged = slices.Clone(merged)
+			replaced = true
+		}
+
+		merged[i] = f
+	}
+
+	return merged, replaced
+}
+
+// SetLevel adjusts the logging level on the given logging context. Changing it resets the
+// sampler of the logging context, if any (see WithLevelSampling).
 //
 // If 'ctx' is not a logging context then this is a no-op.
 func SetLevel(ctx context.Context, level Level) {
-	l, ok := ctx.Value(levelKey).(*zap.AtomicLevel)
+	b, ok := backendOf(ctx)
 	if !ok {
 		return
 	}
 
-	l.SetLevel(zapcore.Level(level))
+	old := b.Level()
+
+	if n, ok := ctx.Value(levelChangeKey).(*levelNotifier); ok {
+		n.set(b, level)
+	} else {
+		b.SetLevel(level)
+	}
+
+	if b.Level() != old {
+		ResetSampler(ctx)
+	}
 }
 
-// DebugEnabled indicates whether DebugLevel is enabled on the given context.
+// WithTemporaryLevel sets the level on the given logging context and returns a function that
+// restores the previous level, eg. to debug a single operation:
+//
+//	defer clog.WithTemporaryLevel(ctx, clog.DebugLevel)()
+//
+// The level is shared by every context derived from the same logging context (see SetLevel),
+// so concurrent requests are affected too until the level is restored.
+//
+// If ctx is not a logging context then this is a no-op.
+func WithTemporaryLevel(ctx context.Context, level Level) (restore func()) {
+	b, ok := backendOf(ctx)
+	if !ok {
+		return func() {}
+	}
+
+	prev := b.Level()
+
+	SetLevel(ctx, level)
+
+	return func() {
+		SetLevel(ctx, prev)
+	}
+}
+
+// Enabled indicates whether the given level is enabled on the given context.
 //
 // If ctx is not a logging context then false is returned.
-func DebugEnabled(ctx context.Context) bool {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
+func Enabled(ctx context.Context, level Level) bool {
+	b, ok := backendOf(ctx)
 	if !ok {
 		return false
 	}
 
-	return logger.Level().Enabled(zapcore.DebugLevel)
+	return b.Enabled(level)
+}
+
+// DebugEnabled indicates whether DebugLevel is enabled on the given context.
+//
+// If ctx is not a logging context then false is returned.
+func DebugEnabled(ctx context.Context) bool {
+	return Enabled(ctx, DebugLevel)
 }
 
 // Debug will log at the DebugLevel.
 func Debug(ctx context.Context, msg string, opts ...Option) {
-	if !DebugEnabled(ctx) {
+	level := escalate(ctx, DebugLevel, opts)
+
+	b, ok := recordBackend(ctx, level, msg, opts)
+	if !ok {
 		return
 	}
 
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
-	if !ok {
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, level, msg, reasonSkipped)
+
 		return
 	}
 
-	logger.Debug(msg, getFields(ctx, opts)...)
+	writeZap(b, level, msg, fields)
 }
 
 // InfoEnabled indicates whether InfoLevel is enabled on the given context.
 //
 // If ctx is not a logging context then false is returned.
 func InfoEnabled(ctx context.Context) bool {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
-	if !ok {
-		return false
-	}
-
-	return logger.Level().Enabled(zapcore.InfoLevel)
+	return Enabled(ctx, InfoLevel)
 }
 
 // Info logs at the InfoLevel.
 func Info(ctx context.Context, msg string, opts ...Option) {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
+	level := escalate(ctx, InfoLevel, opts)
+
+	b, ok := recordBackend(ctx, level, msg, opts)
 	if !ok {
 		return
 	}
 
-	if !logger.Level().Enabled(zapcore.InfoLevel) {
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, level, msg, reasonSkipped)
+
 		return
 	}
 
-	logger.Info(msg, getFields(ctx, opts)...)
+	writeZap(b, level, msg, fields)
 }
 
 // WarnEnabled indicates whether WarnLevel is enabled on the given context.
 //
 // If ctx is not a logging context then false is returned.
 func WarnEnabled(ctx context.Context) bool {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
-	if !ok {
-		return false
-	}
-
-	return logger.Level().Enabled(zapcore.WarnLevel)
+	return Enabled(ctx, WarnLevel)
 }
 
 // Warn logs at the WarnLevel.
 func Warn(ctx context.Context, msg string, opts ...Option) {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
+	level := escalate(ctx, WarnLevel, opts)
+
+	b, ok := recordBackend(ctx, level, msg, opts)
 	if !ok {
 		return
 	}
 
-	if !logger.Level().Enabled(zapcore.WarnLevel) {
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, level, msg, reasonSkipped)
+
 		return
 	}
 
-	logger.Warn(msg, getFields(ctx, opts)...)
+	writeZap(b, level, msg, fields)
 }
 
 // ErrorEnabled indicates whether ErrorLevel is enabled on the given context.
 //
 // If ctx is not a logging context then false is returned.
 func ErrorEnabled(ctx context.Context) bool {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
-	if !ok {
-		return false
-	}
-
-	return logger.Level().Enabled(zapcore.ErrorLevel)
+	return Enabled(ctx, ErrorLevel)
 }
 
 // Error logs at the ErrorLevel.
 func Error(ctx context.Context, msg string, opts ...Option) {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
+	level := escalate(ctx, ErrorLevel, opts)
+
+	b, ok := recordBackend(ctx, level, msg, opts)
 	if !ok {
 		return
 	}
 
-	if !logger.Level().Enabled(zapcore.ErrorLevel) {
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, level, msg, reasonSkipped)
+
 		return
 	}
 
-	logger.Error(msg, getFields(ctx, opts)...)
+	writeZap(b, level, msg, fields)
 }
 
-// Panic logs at the PanicLevel.
+// Panic logs at the PanicLevel, with the stack trace under StacktraceKey, and then panics.
 func Panic(ctx context.Context, msg string, opts ...Option) {
-	logger, ok := ctx.Value(loggerKey).(*zap.Logger)
+	b, ok := recordBackend(ctx, PanicLevel, msg, opts)
+	if !ok {
+		return
+	}
+
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, PanicLevel, msg, reasonSkipped)
+
+		return
+	}
+
+	writeZap(b, PanicLevel, msg, append(fields, stacktrace(ctx)))
+	terminate(PanicLevel, msg)
+}
+
+// Fatal logs at the FatalLevel, with the stack trace under StacktraceKey, and then calls
+// os.Exit(1).
+func Fatal(ctx context.Context, msg string, opts ...Option) {
+	b, ok := recordBackend(ctx, FatalLevel, msg, opts)
 	if !ok {
 		return
 	}
 
-	if !logger.Level().Enabled(zapcore.PanicLevel) {
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, FatalLevel, msg, reasonSkipped)
+
 		return
 	}
 
-	logger.Panic(msg, getFields(ctx, opts)...)
+	writeZap(b, FatalLevel, msg, append(fields, stacktrace(ctx)))
+	terminate(FatalLevel, msg)
 }
 
-func getFields(ctx context.Context, opts []Option) []zap.Field {
+// Log logs at the given level, which may be computed at runtime. Levels below DebugLevel are
+// logged at DebugLevel and those above FatalLevel at FatalLevel; zap's DPanicLevel, between
+// ErrorLevel and PanicLevel, is logged at ErrorLevel. Like Panic and Fatal, records at
+// PanicLevel and FatalLevel carry the stack trace.
+func Log(ctx context.Context, level Level, msg string, opts ...Option) {
+	level = escalate(ctx, clampLevel(level), opts)
+
+	b, ok := recordBackend(ctx, level, msg, opts)
+	if !ok {
+		return
+	}
+
+	// logging directly rather than through Debug, Info, etc. keeps the caller skip the same
+	fields, write := getFields(ctx, opts)
+	if !write {
+		explain(ctx, level, msg, reasonSkipped)
+
+		return
+	}
+
+	if level >= PanicLevel {
+		fields = append(fields, stacktrace(ctx))
+	}
+
+	writeZap(b, level, msg, fields)
+	terminate(level, msg)
+}
+
+// clampLevel returns the level Log logs a record given level at.
+func clampLevel(level Level) Level {
+	switch {
+	case level < DebugLevel:
+		return DebugLevel
+	case level > FatalLevel:
+		return FatalLevel
+	case level == Level(zapcore.DPanicLevel):
+		return ErrorLevel
+	}
+
+	return level
+}
+
+// recordBackend returns the backend to write a record at level with, and false if ctx is not
+// a logging context or the record is below its level and not forced (see WithForce and
+// WithTraceBasedDebug).
+func recordBackend(ctx context.Context, level Level, msg string, opts []Option) (Backend, bool) {
+	b, ok := backendOf(ctx)
+	if !ok || b.Enabled(level) {
+		return b, ok
+	}
+
+	if !forced(opts) && !traceSampled(ctx, level) {
+		explain(ctx, level, msg, reasonBelowLevel)
+
+		return nil, false
+	}
+
+	if zb, ok := b.(*zapBackend); ok {
+		return zb.force(), true
+	}
+
+	return b, true
+}
+
+// forced reports whether opts force the record (see WithForce). The options are applied
+// again to assemble the record's fields, which is fine since forced records are rare.
+func forced(opts []Option) bool {
 	o := &options{}
 
 	for i := range opts {
 		opts[i](o)
 	}
 
-	zf := make([]zap.Field, 0, len(o.fields))
+	return o.force
+}
 
-	for k, v := range o.fields {
-		zf = append(zf, zap.Any(k, v))
+// getFields returns the fields of a log record with opts, and false if the record is to be
+// skipped (see WithSkip).
+func getFields(ctx context.Context, opts []Option) ([]zap.Field, bool) {
+	o := &options{}
+
+	for i := range opts {
+		opts[i](o)
+	}
+
+	if o.skip {
+		return nil, false
 	}
 
+	return appendFields(nil, ctx, o), true
+}
+
+// appendFields appends the fields of a log record assembled from o to zf.
+func appendFields(zf []zap.Field, ctx context.Context, o *options) []zap.Field {
+	addExtractedFields(ctx, o)
+	addDefaultFields(ctx, o)
+
+	zf = slices.Grow(zf, len(o.fields)+1)
+
+	for _, k := range slices.Sorted(maps.Keys(o.fields)) {
+		zf = append(zf, zap.Any(k, o.fields[k]))
+	}
+
+	errs := o.errs
 	if o.err != nil {
-		errKey, ok := ctx.Value(errorKey).(string)
-		if ok {
-			zf = append(zf, zap.NamedError(errKey, o.err))
-		} else {
-			zf = append(zf, zap.NamedError(DefaultErrorKey, o.err))
+		errs = append([]error{o.err}, errs...)
+	}
+
+	if len(errs) > 0 {
+		zf = append(zf, errorFields(ctx, errs)...)
+	}
+
+	if rc, ok := ctx.Value(recordKey).(*recordConfig); ok && rc.deadline != nil {
+		zf = append(zf, rc.deadline.fields()...)
+	}
+
+	for _, ns := range o.namespaces {
+		zf = append(zf, zap.Namespace(ns.name))
+
+		for _, k := range slices.Sorted(maps.Keys(ns.fields)) {
+			zf = append(zf, zap.Any(k, ns.fields[k]))
 		}
 	}
 
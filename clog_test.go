@@ -0,0 +1,721 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// newFileContext returns a JSON logging context writing to a temporary file, along with a
+// function that returns the records written so far.
+func newFileContext(
+	t *testing.T, opts ...ContextOption,
+) (context.Context, func() []map[string]any) {
+	t.Helper()
+
+	return newFileContextFrom(t, context.Background(), opts...)
+}
+
+// newFileContextFrom is like newFileContext but derives the logging context from parent.
+func newFileContextFrom(
+	t *testing.T, parent context.Context, opts ...ContextOption,
+) (context.Context, func() []map[string]any) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.log")
+
+	opts = append([]ContextOption{
+		WithJSONEncoding(),
+		WithNoTimeKey(),
+		WithRotatingFile(path, 0, 0, 0),
+	}, opts...)
+
+	ctx, err := NewContext(parent, opts...)
+	if err != nil {
+		t.Fatalf("failed to create logging context: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := Close(ctx); err != nil {
+			t.Errorf("failed to close logging context: %v", err)
+		}
+	})
+
+	return ctx, func() []map[string]any {
+		t.Helper()
+
+		return readRecords(t, path)
+	}
+}
+
+func readRecords(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open log file: %v", err)
+	}
+	defer f.Close()
+
+	var records []map[string]any
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 10*megabyte)
+
+	for scanner.Scan() {
+		record := map[string]any{}
+
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid JSON record %q: %v", scanner.Text(), err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	return records
+}
+
+func requireRecords(t *testing.T, records []map[string]any, n int) {
+	t.Helper()
+
+	if len(records) != n {
+		t.Fatalf("expected %d records, got %d: %v", n, len(records), records)
+	}
+}
+
+func TestContextNotLoggingContext(t *testing.T) {
+	ctx := context.Background()
+
+	Info(ctx, "nothing happens")
+
+	if InfoEnabled(ctx) {
+		t.Error("expected InfoEnabled to be false for a plain context")
+	}
+
+	if err := Close(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestIsLoggingContext(t *testing.T) {
+	ctx := Context(nil, WithBackend(NewMemoryBackend(InfoLevel)))
+
+	if !IsLoggingContext(ctx) || !IsLoggingContext(ContextWithField(ctx, "a", 1)) {
+		t.Error("expected a logging context")
+	}
+
+	if IsLoggingContext(context.Background()) {
+		t.Error("expected the background context not to be a logging context")
+	}
+}
+
+func TestContextWithFields(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	ctx = ContextWithField(ctx, "foo", "bar")
+	ctx = ContextWithFields(ctx, Fields{"a": 1.0})
+
+	Info(ctx, "hello", WithField("z", "y"))
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	want := map[string]any{"severity": "INFO", "msg": "hello", "foo": "bar", "a": 1.0, "z": "y"}
+	for k, v := range want {
+		if records[0][k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, records[0][k])
+		}
+	}
+}
+
+func TestContextWithZapFields(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	ctx, done := CanonicalContext(ctx)
+	ctx = ContextWithFields(ctx, Fields{"a": 1, "b": 2})
+	ctx = ContextWithZapFields(ctx, zap.Int("a", 10), zap.Strings("tags", []string{"x", "y"}))
+
+	Info(ctx, "hello")
+	done("done")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	for i, r := range records {
+		if r["a"] != 10.0 || r["b"] != 2.0 || fmt.Sprint(r["tags"]) != "[x y]" {
+			t.Errorf("unexpected record %d: %v", i, r)
+		}
+	}
+
+	if got := ContextWithZapFields(context.Background(), zap.Int("a", 1)); IsLoggingContext(got) {
+		t.Error("expected a non-logging context to be returned as-is")
+	}
+}
+
+func BenchmarkContextWithFields(b *testing.B) {
+	ctx := Context(context.Background(),
+		WithRotatingFile(filepath.Join(b.TempDir(), "bench.log"), 0, 0, 0),
+	)
+	defer Close(ctx)
+
+	b.Run("ContextWithFields", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			ContextWithFields(ctx, Fields{"service": "api", "region": "eu", "replica": 3})
+		}
+	})
+
+	b.Run("ContextWithZapFields", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			ContextWithZapFields(ctx,
+				zap.String("service", "api"), zap.String("region", "eu"), zap.Int("replica", 3),
+			)
+		}
+	})
+}
+
+func TestContextWithFieldsOverride(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithVersion("v1", ""))
+
+	ctx = ContextWithField(ctx, "a", 1)
+	ctx = ContextWithFields(ctx, Fields{"b": 2, "c": 3})
+	child := ContextWithFields(ctx, Fields{"a": 10, VersionKey: "v2"})
+	child = ContextWithClock(child, time.Now)
+	child = ContextWithField(child, "b", 20)
+	grandchild := ContextWithIndependentLevel(ContextWithField(child, "c", 30), InfoLevel)
+	grandchild = ContextWithField(grandchild, "a", 100)
+
+	Info(ctx, "parent")
+	Info(child, "child")
+	Info(grandchild, "grandchild")
+
+	want := `{"severity":"INFO","msg":"parent","version":"v1","a":1,"b":2,"c":3}` + "\n" +
+		`{"severity":"INFO","msg":"child","version":"v2","a":10,"b":20,"c":3}` + "\n" +
+		`{"severity":"INFO","msg":"grandchild","version":"v2","a":100,"b":20,"c":30}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestContextWithFieldOverrideHooks(t *testing.T) {
+	hook, entries := recorder()
+
+	ctx, read := newRawContext(t, WithJSONEncoding())
+
+	ctx = ContextWithField(ctx, "a", 1)
+	ctx = ContextWithEntryCallback(ctx, hook)
+	ctx = ContextWithField(ctx, "a", 2)
+
+	Info(ctx, "x")
+
+	if got, want := read(), `{"severity":"INFO","msg":"x","a":2}`+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if len(*entries) != 1 || (*entries)[0].fields["a"] != int64(2) {
+		t.Errorf("expected the hook to see the overriding field, got %v", *entries)
+	}
+}
+
+func TestNewContextInvalidOutput(t *testing.T) {
+	// a path below a regular file can never be created
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(file, "dir", "app.log")
+
+	ctx, err := NewContext(context.Background(), WithRotatingFile(path, 0, 0, 0))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if ctx != nil {
+		t.Error("expected a nil context on error")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Context to panic")
+		}
+	}()
+
+	Context(context.Background(), WithRotatingFile(path, 0, 0, 0))
+}
+
+func TestWithOutputFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "app.log")
+
+	_, stderr := captureStd(t, func() {
+		ctx, err := NewContext(context.Background(),
+			WithOutputPath(path), WithOutputFallback(), WithJSONEncoding(), WithNoTimeKey())
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+
+			return
+		}
+
+		Info(ctx, "still logging")
+	})
+
+	lines := strings.Split(strings.TrimSuffix(stderr, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a warning and the record on stderr, got %q", stderr)
+	}
+
+	if !strings.Contains(lines[0], `"severity":"WARN"`) || !strings.Contains(lines[0], path) {
+		t.Errorf("expected a warning about the output path, got %q", lines[0])
+	}
+
+	if !strings.Contains(lines[1], `"msg":"still logging"`) {
+		t.Errorf("expected the record, got %q", lines[1])
+	}
+}
+
+func TestNewContextNilParent(t *testing.T) {
+	//nolint:staticcheck // a nil parent is explicitly supported
+	ctx, err := NewContext(nil, OutputToStdout())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !InfoEnabled(ctx) {
+		t.Error("expected a logging context")
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	ctx, _ := newFileContext(t, WithLevel(WarnLevel))
+
+	for level, want := range map[Level]bool{
+		DebugLevel: false,
+		InfoLevel:  false,
+		WarnLevel:  true,
+		ErrorLevel: true,
+		PanicLevel: true,
+	} {
+		if got := Enabled(ctx, level); got != want {
+			t.Errorf("expected Enabled(%s)=%v, got %v", level, want, got)
+		}
+	}
+
+	if DebugEnabled(ctx) || InfoEnabled(ctx) || !WarnEnabled(ctx) || !ErrorEnabled(ctx) {
+		t.Error("per-level helpers disagree with Enabled")
+	}
+
+	if Enabled(context.Background(), PanicLevel) {
+		t.Error("expected false for a plain context")
+	}
+}
+
+func TestLog(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	for _, level := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		Log(ctx, level, level.String())
+	}
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	for i, want := range []string{"INFO", "WARN", "ERROR"} {
+		if records[i]["severity"] != want {
+			t.Errorf("expected %s, got %v", want, records[i])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Log at PanicLevel to panic")
+		}
+	}()
+
+	Log(ctx, PanicLevel, "boom")
+}
+
+func TestLogClamp(t *testing.T) {
+	for level, want := range map[Level]Level{
+		DebugLevel - 1:             DebugLevel,
+		InfoLevel:                  InfoLevel,
+		ErrorLevel:                 ErrorLevel,
+		Level(zapcore.DPanicLevel): ErrorLevel,
+		PanicLevel:                 PanicLevel,
+		FatalLevel:                 FatalLevel,
+		FatalLevel + 1:             FatalLevel,
+	} {
+		if got := clampLevel(level); got != want {
+			t.Errorf("expected %s for %d, got %s", want, level, got)
+		}
+	}
+
+	ctx, read := newFileContext(t)
+
+	// must neither panic nor exit
+	Log(ctx, ErrorLevel+1, "dpanic")
+
+	if r := read()[0]; r["severity"] != "ERROR" {
+		t.Errorf("expected an error, got %v", r)
+	}
+}
+
+func TestPanicStacktrace(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	for _, panics := range []func(){
+		func() { Panic(ctx, "boom") },
+		func() { Log(ctx, PanicLevel, "boom") },
+	} {
+		func() {
+			defer func() { _ = recover() }()
+
+			panics()
+		}()
+	}
+
+	Error(ctx, "no stack trace")
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	for _, r := range records[:2] {
+		stack, _ := r[StacktraceKey].(string)
+
+		// the stack trace starts at the caller of the logging function
+		if !strings.HasPrefix(stack, "github.com/terminalstream/clog.TestPanicStacktrace.func") {
+			t.Errorf("unexpected stack trace: %q", stack)
+		}
+	}
+
+	if _, ok := records[2][StacktraceKey]; ok {
+		t.Error("expected no stack trace below PanicLevel")
+	}
+}
+
+func TestLogNotLoggingContext(t *testing.T) {
+	Log(context.Background(), PanicLevel, "nothing happens")
+	Log(context.Background(), FatalLevel, "nothing happens")
+}
+
+func TestCopyContext(t *testing.T) {
+	from, read := newFileContext(t, WithErrorKey("err"))
+
+	type key struct{}
+
+	to := context.WithValue(context.Background(), key{}, "value")
+
+	ctx := CopyContext(to, from)
+
+	if ctx.Value(key{}) != "value" {
+		t.Error("expected the copy to derive from 'to'")
+	}
+
+	SetLevel(ctx, DebugLevel)
+
+	if !DebugEnabled(from) {
+		t.Error("expected SetLevel on the copy to affect the original")
+	}
+
+	Error(ctx, "failed", WithError(errors.New("boom")))
+
+	if got := read()[0]["err"]; got != "boom" {
+		t.Errorf("expected the error under the custom key, got %v", got)
+	}
+}
+
+func TestCopyContextNotLoggingContext(t *testing.T) {
+	to := context.Background()
+
+	if CopyContext(to, context.TODO()) != to {
+		t.Error("expected 'to' to be returned as-is")
+	}
+}
+
+func TestSetLevelOnDerivedContexts(t *testing.T) {
+	parent, _ := newFileContext(t)
+
+	child := ContextWithField(parent, "a", 1)
+	grandchild := ContextWithFields(child, Fields{"b": 2})
+
+	SetLevel(grandchild, DebugLevel)
+
+	for name, ctx := range map[string]context.Context{
+		"parent": parent, "child": child, "grandchild": grandchild,
+	} {
+		if !DebugEnabled(ctx) {
+			t.Errorf("expected Debug to be enabled on %s", name)
+		}
+	}
+
+	SetLevel(parent, ErrorLevel)
+
+	if InfoEnabled(grandchild) {
+		t.Error("expected SetLevel on the parent to affect descendants")
+	}
+}
+
+// newRawContext returns a logging context writing to a temporary file, along with a function
+// that returns the raw output written so far.
+func newRawContext(t *testing.T, opts ...ContextOption) (context.Context, func() string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.log")
+
+	ctx, err := NewContext(context.Background(), append(opts,
+		WithNoTimeKey(),
+		WithRotatingFile(path, 0, 0, 0),
+	)...)
+	if err != nil {
+		t.Fatalf("failed to create logging context: %v", err)
+	}
+
+	t.Cleanup(func() { _ = Close(ctx) })
+
+	return ctx, func() string {
+		t.Helper()
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read log file: %v", err)
+		}
+
+		return string(b)
+	}
+}
+
+func TestWithColorLevels(t *testing.T) {
+	ctx, read := newRawContext(t, WithColorLevels())
+
+	Error(ctx, "x")
+
+	if got := read(); !strings.HasPrefix(got, "\x1b[31mERROR\x1b[0m") {
+		t.Errorf("expected a red level, got %q", got)
+	}
+
+	ctx, read = newRawContext(t, WithColorLevels(), WithJSONEncoding())
+
+	Error(ctx, "x")
+
+	if got := read(); strings.Contains(got, "\x1b[") {
+		t.Errorf("expected no colors with JSON encoding, got %q", got)
+	}
+}
+
+func TestWithLevelFromEnv(t *testing.T) {
+	t.Setenv("TEST_LOG_LEVEL", "debug")
+
+	ctx, _ := newFileContext(t, WithLevelFromEnv("TEST_LOG_LEVEL"))
+
+	if !DebugEnabled(ctx) {
+		t.Error("expected the level from the environment")
+	}
+}
+
+func TestWithLevelFromEnvUnset(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(WarnLevel), WithLevelFromEnv("TEST_LOG_LEVEL_UNSET"))
+
+	if InfoEnabled(ctx) || !WarnEnabled(ctx) {
+		t.Error("expected the level to be left as is")
+	}
+
+	requireRecords(t, read(), 0)
+}
+
+func TestWithLevelFromEnvInvalid(t *testing.T) {
+	t.Setenv("TEST_LOG_LEVEL", "loud")
+
+	ctx, read := newFileContext(t, WithLevelFromEnv("TEST_LOG_LEVEL"))
+
+	if DebugEnabled(ctx) || !InfoEnabled(ctx) {
+		t.Error("expected the default level")
+	}
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if records[0]["severity"] != "WARN" || records[0]["value"] != "loud" {
+		t.Errorf("unexpected warning %v", records[0])
+	}
+}
+
+func TestWithNoMessageKey(t *testing.T) {
+	for _, tc := range []struct {
+		encoding ContextOption
+		want     string
+	}{
+		{WithJSONEncoding(), `{"severity":"INFO","a":1}` + "\n"},
+		{WithConsoleEncoding(), "INFO\t{\"a\": 1}\n"},
+	} {
+		ctx, read := newRawContext(t, tc.encoding, WithNoMessageKey())
+
+		Info(ctx, "", WithField("a", 1))
+
+		if got := read(); got != tc.want {
+			t.Errorf("expected %q, got %q", tc.want, got)
+		}
+	}
+}
+
+// prefixEncoder prefixes every encoded entry, to tell it apart from the built-in encoders.
+type prefixEncoder struct {
+	zapcore.Encoder
+}
+
+func (e prefixEncoder) Clone() zapcore.Encoder {
+	return prefixEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e prefixEncoder) EncodeEntry(
+	entry zapcore.Entry, fields []zapcore.Field,
+) (*buffer.Buffer, error) {
+	b, err := e.Encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	out := buffer.NewPool().Get()
+	out.AppendString("custom ")
+	out.Write(b.Bytes())
+	b.Free()
+
+	return out, nil
+}
+
+func TestWithEncoder(t *testing.T) {
+	enc := prefixEncoder{Encoder: zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "m"})}
+
+	ctx, read := newRawContext(t, WithConsoleEncoding(), WithEncoder(enc))
+
+	Info(ContextWithField(ctx, "a", 1), "x", WithField("b", 2))
+
+	if got, want := read(), `custom {"m":"x","a":1,"b":2}`+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithEncoderConfig(t *testing.T) {
+	config := zapcore.EncoderConfig{
+		MessageKey:  "message",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+		LineEnding:  "\r\n",
+	}
+
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithEncoderConfig(config),
+		WithLevelKey("severity"))
+
+	Info(ctx, "x", WithField("a", 1))
+	Warn(ctx, "y")
+
+	want := `{"level":"info","message":"x","a":1}` + "\r\n" +
+		`{"level":"warn","message":"y"}` + "\r\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithConsoleSeparator(t *testing.T) {
+	for _, sep := range []string{"\t", " | "} {
+		ctx, read := newRawContext(t, WithConsoleSeparator(sep))
+
+		Info(ctx, "x", WithField("a", 1))
+
+		if got, want := read(), "INFO"+sep+"x"+sep+`{"a": 1}`+"\n"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithConsoleSeparator(" | "))
+
+	Info(ctx, "x")
+
+	if got, want := read(), `{"severity":"INFO","msg":"x"}`+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithLineEnding(t *testing.T) {
+	for _, tc := range []struct {
+		encoding ContextOption
+		want     string
+	}{
+		{WithJSONEncoding(), `{"severity":"INFO","msg":"x"}` + "\r\n"},
+		{WithConsoleEncoding(), "INFO\tx\r\n"},
+	} {
+		ctx, read := newRawContext(t, tc.encoding, WithLineEnding("\r\n"))
+
+		Info(ctx, "x")
+
+		if got := read(); got != tc.want {
+			t.Errorf("expected %q, got %q", tc.want, got)
+		}
+	}
+
+	if _, err := NewContext(context.Background(), WithLineEnding("")); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestContextWithFieldOK(t *testing.T) {
+	plain := context.Background()
+
+	if ctx, ok := ContextWithFieldOK(plain, "a", 1); ok || ctx != plain {
+		t.Error("expected a plain context to be returned as-is")
+	}
+
+	if ctx, ok := ContextWithFieldsOK(plain, Fields{"a": 1}); ok || ctx != plain {
+		t.Error("expected a plain context to be returned as-is")
+	}
+
+	ctx, read := newFileContext(t)
+
+	ctx, ok := ContextWithFieldOK(ctx, "a", 1)
+	if !ok {
+		t.Error("expected the field to be added to a logging context")
+	}
+
+	ctx, ok = ContextWithFieldsOK(ctx, Fields{"b": 2})
+	if !ok {
+		t.Error("expected the fields to be added to a logging context")
+	}
+
+	Info(ctx, "x")
+
+	if r := read()[0]; r["a"] != 1.0 || r["b"] != 2.0 {
+		t.Errorf("expected the added fields, got %v", r)
+	}
+}
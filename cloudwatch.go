@@ -0,0 +1,33 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap/zapcore"
+
+// WithCloudWatchDefaults lays out records the way AWS CloudWatch Logs Insights queries them
+// best: JSON encoded, with the time as milliseconds since the epoch under "timestamp", the
+// level under "level" and the message under "message". Insights discovers nested fields
+// (eg. those of WithNamespace) by their dotted path, so they're queryable as-is.
+//
+// Options given after it override the respective settings.
+func WithCloudWatchDefaults() ContextOption {
+	return func(o *contextOptions) {
+		o.encoding = "json"
+		o.levelKey = "level"
+		o.msgKey = "message"
+		o.timeKey = "timestamp"
+		o.encodeTime = zapcore.EpochMillisTimeEncoder
+	}
+}
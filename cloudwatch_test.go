@@ -0,0 +1,46 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithCloudWatchDefaults(t *testing.T) {
+	o := &contextOptions{}
+	WithCloudWatchDefaults()(o)
+
+	config := o.encoderConfig()
+
+	if o.encoding != "json" || config.LevelKey != "level" || config.MessageKey != "message" ||
+		config.TimeKey != "timestamp" {
+		t.Errorf("unexpected encoding %q and config %+v", o.encoding, config)
+	}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ctx, read := newFileContext(t, WithCloudWatchDefaults(),
+		WithClock(func() time.Time { return now }),
+	)
+
+	Info(ctx, "x")
+
+	r := read()[0]
+
+	if r["timestamp"] != float64(now.UnixMilli()) || r["level"] != "INFO" || r["message"] != "x" {
+		t.Errorf("unexpected record %v", r)
+	}
+}
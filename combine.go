@@ -0,0 +1,139 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Combine returns a logging context that fans out every log record to all of the given
+// logging contexts. Contexts that are nil, not logging contexts or not writing with zap (see
+// WithBackend) are ignored.
+//
+// Each underlying logger keeps its own configuration (encoding, keys, output) and its own
+// level, which can still be adjusted with SetLevel on the original contexts; SetLevel on the
+// combined context is a no-op. Errors are logged under each context's own error key.
+//
+// The returned context is derived from the first non-nil context given, or from the
+// background context if there is none.
+func Combine(contexts ...context.Context) context.Context {
+	var (
+		parent context.Context
+		cores  []zapcore.Core
+		errKey string
+	)
+
+	for _, ctx := range contexts {
+		if ctx == nil {
+			continue
+		}
+
+		if parent == nil {
+			parent = ctx
+		}
+
+		b, ok := zapBackendOf(ctx)
+		if !ok {
+			continue
+		}
+
+		key := errorKeyOf(ctx)
+
+		if cores == nil {
+			errKey = key
+		}
+
+		core := b.logger.Core()
+
+		if key != errKey {
+			core = &renameErrorCore{Core: core, from: errKey, to: key}
+		}
+
+		cores = append(cores, core)
+	}
+
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	if len(cores) == 0 {
+		return parent
+	}
+
+	// the combined backend has no level of its own so that SetLevel doesn't affect just one
+	// of the loggers
+	b := &zapBackend{logger: zap.New(zapcore.NewTee(cores...))}
+
+	ctx := context.WithValue(parent, loggerKey, b)
+	ctx = context.WithValue(ctx, errorKey, errKey)
+	// a combined context can't be rebuilt from a single configuration or without its fields,
+	// nor has a single tail or sampler
+	ctx = context.WithValue(ctx, configKey, nil)
+	ctx = context.WithValue(ctx, baseKey, nil)
+	ctx = context.WithValue(ctx, tailKey, nil)
+	ctx = context.WithValue(ctx, samplerKey, nil)
+
+	return ctx
+}
+
+func errorKeyOf(ctx context.Context) string {
+	if key, ok := ctx.Value(errorKey).(string); ok {
+		return key
+	}
+
+	return DefaultErrorKey
+}
+
+// renameErrorCore renames the error fields logged under one key to another.
+type renameErrorCore struct {
+	zapcore.Core
+	from string
+	to   string
+}
+
+func (c *renameErrorCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *renameErrorCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.rename(fields))
+}
+
+func (c *renameErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &renameErrorCore{
+		Core: c.Core.With(c.rename(fields)),
+		from: c.from,
+		to:   c.to,
+	}
+}
+
+func (c *renameErrorCore) rename(fields []zapcore.Field) []zapcore.Field {
+	renamed := make([]zapcore.Field, len(fields))
+
+	for i, f := range fields {
+		if isErrorField(f) && f.Key == c.from {
+			f.Key = c.to
+		}
+
+		renamed[i] = f
+	}
+
+	return renamed
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newObservedContext returns a logging context backed by an observer core.
+func newObservedContext(level Level, errKey string) (context.Context, *observer.ObservedLogs) {
+	atomic := zap.NewAtomicLevelAt(zapcore.Level(level))
+	core, logs := observer.New(atomic)
+
+	b := &zapBackend{logger: zap.New(core), level: &atomic}
+
+	ctx := context.WithValue(context.Background(), loggerKey, b)
+	ctx = context.WithValue(ctx, errorKey, errKey)
+
+	return ctx, logs
+}
+
+func TestCombine(t *testing.T) {
+	audit, auditLogs := newObservedContext(InfoLevel, "audit_error")
+	ops, opsLogs := newObservedContext(WarnLevel, DefaultErrorKey)
+
+	ctx := Combine(nil, audit, context.Background(), ops)
+
+	Info(ctx, "info")
+	Warn(ctx, "warn", WithError(errors.New("boom")))
+
+	if auditLogs.Len() != 2 {
+		t.Errorf("expected 2 audit entries, got %d", auditLogs.Len())
+	}
+
+	if opsLogs.Len() != 1 || opsLogs.All()[0].Message != "warn" {
+		t.Errorf("expected only the warning on ops, got %v", opsLogs.All())
+	}
+
+	if got := auditLogs.All()[1].ContextMap()["audit_error"]; got != "boom" {
+		t.Errorf("expected error under audit_error, got %v", auditLogs.All()[1].ContextMap())
+	}
+
+	if got := opsLogs.All()[0].ContextMap()[DefaultErrorKey]; got != "boom" {
+		t.Errorf("expected error under error, got %v", opsLogs.All()[0].ContextMap())
+	}
+
+	SetLevel(ctx, DebugLevel)
+
+	if DebugEnabled(audit) || DebugEnabled(ops) {
+		t.Error("SetLevel on the combined context must not affect the originals")
+	}
+}
+
+func TestCombineWithoutLoggingContexts(t *testing.T) {
+	if ctx := Combine(); ctx == nil {
+		t.Error("expected a non-nil context")
+	}
+
+	if ctx := Combine(nil, context.Background()); InfoEnabled(ctx) {
+		t.Error("expected a non-logging context")
+	}
+}
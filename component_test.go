@@ -0,0 +1,79 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithAutoComponent(t *testing.T) {
+	ctx, read := newFileContext(t, WithAutoComponent())
+
+	Info(ctx, "x")
+	Log(ctx, WarnLevel, "x")
+	LogRetry(ctx, 1, 2, 0, nil)
+	Info(ctx, "x", WithField(ComponentKey, "orders"))
+
+	records := read()
+	requireRecords(t, records, 4)
+
+	for _, r := range records[:3] {
+		if r[ComponentKey] != clogPackage {
+			t.Errorf("expected %q as component, got %v", clogPackage, r)
+		}
+	}
+
+	if records[3][ComponentKey] != "orders" {
+		t.Errorf("expected the record's component to win, got %v", records[3])
+	}
+}
+
+func TestPackageOf(t *testing.T) {
+	for function, want := range map[string]string{
+		"github.com/acme/shop/orders.(*Service).Create": "github.com/acme/shop/orders",
+		"github.com/acme/shop/orders.Create.func1":      "github.com/acme/shop/orders",
+		"main.main": "main",
+	} {
+		if got := packageOf(function); got != want {
+			t.Errorf("expected %q for %q, got %q", want, function, got)
+		}
+	}
+}
+
+func BenchmarkAutoComponent(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		opts  []ContextOption
+		level Level
+	}{
+		{"Off", nil, InfoLevel},
+		{"On", []ContextOption{WithAutoComponent()}, InfoLevel},
+		{"OnBelowLevel", []ContextOption{WithAutoComponent()}, DebugLevel},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := Context(context.Background(), append(bc.opts,
+				WithJSONEncoding(),
+				WithRotatingFile(filepath.Join(b.TempDir(), "bench.log"), 0, 0, 0),
+			)...)
+			defer Close(ctx)
+
+			for range b.N {
+				Log(ctx, bc.level, "x")
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"slices"
+)
+
+// Config is the configuration of a logging context, as given by the options it was created
+// with (see ConfigFromContext). The exported fields can be inspected and changed before
+// building a new logging context with ContextFromConfig; the rest of the configuration (eg.
+// hooks or the rotating file) is carried over as-is.
+type Config struct {
+	Encoding   string
+	Level      Level
+	OutputPath string
+	LevelKey   string
+	MessageKey string
+	TimeKey    string
+	ErrorKey   string
+	// MaxFieldBytes and MaxFields are the limits of WithMaxFieldBytes and WithMaxFields
+	MaxFieldBytes int
+	MaxFields     int
+
+	options contextOptions
+}
+
+func newConfig(o *contextOptions) *Config {
+	c := &Config{options: *o}
+
+	// the problems found while applying the options have been reported already
+	c.options.setupLogs = nil
+	c.options.fields = slices.Clip(o.fields)
+	c.options.hooks = slices.Clip(o.hooks)
+	c.options.levelAliases = slices.Clip(o.levelAliases)
+	c.options.record.extractors = slices.Clip(o.record.extractors)
+
+	return c
+}
+
+// ConfigFromContext returns the configuration of the logging context ctx: that given by the
+// options it was created with, and its current level. Fields added afterwards (eg. with
+// ContextWithField) are not part of it.
+//
+// If ctx is not a logging context, or is a combination of logging contexts (see Combine),
+// then false is returned.
+func ConfigFromContext(ctx context.Context) (Config, bool) {
+	c, ok := ctx.Value(configKey).(*Config)
+	if !ok || c == nil {
+		return Config{}, false
+	}
+
+	config := *c
+	o := &config.options
+
+	if b, ok := backendOf(ctx); ok {
+		o.level = b.Level()
+	}
+
+	config.Encoding = o.encoding
+	config.Level = o.level
+	config.OutputPath = o.outputPath
+	config.LevelKey = o.levelKey
+	config.MessageKey = o.msgKey
+	config.TimeKey = o.timeKey
+	config.ErrorKey = o.errorKey
+	config.MaxFieldBytes = o.maxBytes
+	config.MaxFields = o.maxFields
+
+	return config, true
+}
+
+// ContextFromConfig returns a new logging context built from config, which must have been
+// obtained with ConfigFromContext, like NewContext does from options.
+//
+// The new logging context opens its own output. Changing OutputPath replaces the rotating
+// file (see WithRotatingFile), if any; beware of writing to the same rotating file from
+// several logging contexts otherwise.
+func ContextFromConfig(parent context.Context, config Config) (context.Context, error) {
+	return NewContext(parent, func(o *contextOptions) {
+		*o = config.options
+
+		if config.OutputPath != o.outputPath {
+			o.rotation = nil
+		}
+
+		o.encoding = config.Encoding
+		o.level = config.Level
+		o.outputPath = config.OutputPath
+		o.levelKey = config.LevelKey
+		o.msgKey = config.MessageKey
+		o.timeKey = config.TimeKey
+		o.errorKey = config.ErrorKey
+		o.maxBytes = config.MaxFieldBytes
+		o.maxFields = config.MaxFields
+
+		// the new logging context samples its records on its own
+		if s := o.levelSampling; s != nil {
+			o.levelSampling = newLevelSampler(s.level, s.initial, s.thereafter)
+		}
+	})
+}
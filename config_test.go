@@ -0,0 +1,127 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+
+	hook, entries := recorder()
+
+	ctx, err := NewContext(context.Background(),
+		WithJSONEncoding(),
+		WithNoTimeKey(),
+		WithMessageKey("message"),
+		WithOutputPath(first),
+		WithHooks(hook),
+		WithRegion("eu-west-1"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create logging context: %v", err)
+	}
+
+	t.Cleanup(func() { _ = Close(ctx) })
+
+	SetLevel(ctx, DebugLevel)
+
+	config, ok := ConfigFromContext(ContextWithField(ctx, "a", 1))
+	if !ok {
+		t.Fatal("expected the configuration of a logging context")
+	}
+
+	if config.Encoding != "json" || config.Level != DebugLevel || config.MessageKey != "message" ||
+		config.OutputPath != first {
+		t.Errorf("unexpected configuration %+v", config)
+	}
+
+	config.OutputPath = second
+
+	rebuilt, err := ContextFromConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("failed to rebuild logging context: %v", err)
+	}
+
+	t.Cleanup(func() { _ = Close(rebuilt) })
+
+	Debug(ctx, "x", WithField("b", 2))
+	Debug(rebuilt, "x", WithField("b", 2))
+
+	got, want := readFile(t, second), readFile(t, first)
+	if got == "" || got != want {
+		t.Errorf("expected %q from the rebuilt context, got %q", want, got)
+	}
+
+	if len(*entries) != 2 {
+		t.Errorf("expected the hooks to be carried over, got %d entries", len(*entries))
+	}
+}
+
+func TestConfigFromContext(t *testing.T) {
+	if _, ok := ConfigFromContext(context.Background()); ok {
+		t.Error("expected no configuration for a non-logging context")
+	}
+
+	ctx, _ := newFileContext(t)
+
+	if _, ok := ConfigFromContext(Combine(ctx, ctx)); ok {
+		t.Error("expected no configuration for combined contexts")
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	return string(b)
+}
+
+func TestContextFromConfigSampler(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevelSampling(InfoLevel, 1, 0))
+
+	config, _ := ConfigFromContext(ctx)
+	config.OutputPath = filepath.Join(t.TempDir(), "rebuilt.log")
+
+	rebuilt, err := ContextFromConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("failed to rebuild logging context: %v", err)
+	}
+
+	t.Cleanup(func() { _ = Close(rebuilt) })
+
+	if ctx.Value(samplerKey) == rebuilt.Value(samplerKey) {
+		t.Fatal("expected the rebuilt context to have its own sampler")
+	}
+
+	// the rebuilt context's records don't count against the original's
+	Info(rebuilt, "x")
+	Info(ctx, "x")
+
+	requireRecords(t, read(), 1)
+
+	if got := readFile(t, config.OutputPath); got == "" {
+		t.Error("expected the rebuilt context to write its first record")
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap/zapcore"
+
+// checkEnabled adds core to checked if it enables the entry's level. It's the Check of the
+// cores wrapping another one: they decide in Write whether and how to write the entry, since
+// the cores wrapping them add themselves to the checked entry without consulting their wrapped
+// core's Check. The cores they wrap are thus written to without being checked, see teeCore.
+func checkEnabled(
+	core zapcore.Core, entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	if core.Enabled(entry.Level) {
+		return checked.AddCore(entry, core)
+	}
+
+	return checked
+}
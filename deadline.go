@@ -0,0 +1,96 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DeadlinePressureKey is the key that flags records logged close to the context's deadline
+// (see WithDeadlinePressure).
+const DeadlinePressureKey = "deadline_pressure"
+
+// deadlineBudget is the time budget of the parent context at the time the logging context
+// was created.
+type deadlineBudget struct {
+	threshold float64
+	start     time.Time
+	deadline  time.Time
+	now       func() time.Time
+}
+
+// WithDeadlinePressure flags log records with "deadline_pressure": true when the remaining
+// time until the context's deadline is below threshold (a fraction between 0 and 1) of the
+// original budget, ie. the time left when the logging context was created.
+//
+// It has no effect if the parent context given to Context/NewContext has no deadline.
+func WithDeadlinePressure(threshold float64) ContextOption {
+	return func(o *contextOptions) {
+		o.deadlineThreshold = threshold
+	}
+}
+
+func newDeadlineBudget(
+	parent context.Context, threshold float64, now func() time.Time,
+) *deadlineBudget {
+	deadline, ok := parent.Deadline()
+	if !ok || threshold <= 0 {
+		return nil
+	}
+
+	return &deadlineBudget{
+		threshold: threshold,
+		start:     now(),
+		deadline:  deadline,
+		now:       now,
+	}
+}
+
+func (b *deadlineBudget) fields() []zap.Field {
+	budget := b.deadline.Sub(b.start)
+	remaining := b.deadline.Sub(b.now())
+
+	if budget <= 0 || float64(remaining)/float64(budget) < b.threshold {
+		return []zap.Field{zap.Bool(DeadlinePressureKey, true)}
+	}
+
+	return nil
+}
+
+// DeadlineRemainingKey is the key that has the time left until the context's deadline as
+// value (see DeadlineWarning).
+const DeadlineRemainingKey = "deadline_remaining"
+
+// DeadlineWarning logs msg at WarnLevel, with the time left under "deadline_remaining", if
+// ctx's deadline is less than threshold away; or at ErrorLevel if it has already passed. It
+// does nothing if ctx has no deadline.
+func DeadlineWarning(ctx context.Context, threshold time.Duration, msg string) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+
+	remaining := time.Until(deadline)
+
+	switch {
+	case remaining <= 0:
+		Error(skipCallers(ctx, 1), msg, WithField(DeadlineRemainingKey, remaining))
+	case remaining < threshold:
+		Warn(skipCallers(ctx, 1), msg, WithField(DeadlineRemainingKey, remaining))
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDeadlinePressure(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	parent, cancel := context.WithDeadline(context.Background(), now.Add(10*time.Second))
+	defer cancel()
+
+	ctx, read := newFileContextFrom(t, parent, WithClock(clock), WithDeadlinePressure(0.2))
+
+	Info(ctx, "plenty of time")
+
+	now = now.Add(7 * time.Second)
+	Info(ctx, "30% left")
+
+	now = now.Add(2 * time.Second)
+	Info(ctx, "10% left")
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	for i, want := range []any{nil, nil, true} {
+		if got := records[i][DeadlinePressureKey]; got != want {
+			t.Errorf("record %d: expected %s=%v, got %v", i, DeadlinePressureKey, want, got)
+		}
+	}
+}
+
+func TestWithDeadlinePressureNoDeadline(t *testing.T) {
+	ctx, read := newFileContext(t, WithDeadlinePressure(1))
+
+	Info(ctx, "x")
+
+	if _, ok := read()[0][DeadlinePressureKey]; ok {
+		t.Error("expected no flag without a deadline")
+	}
+}
+
+func TestDeadlineWarning(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	DeadlineWarning(ctx, time.Second, "no deadline")
+
+	soon, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	DeadlineWarning(soon, time.Second, "far")
+	DeadlineWarning(soon, time.Hour, "close")
+
+	past, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	DeadlineWarning(past, time.Second, "past")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if r := records[0]; r["msg"] != "close" || r["severity"] != "WARN" {
+		t.Errorf("expected a warning, got %v", r)
+	} else if remaining, _ := r[DeadlineRemainingKey].(float64); remaining <= 0 ||
+		remaining > float64(time.Minute) {
+		t.Errorf("expected up to a minute remaining, got %v", r[DeadlineRemainingKey])
+	}
+
+	if r := records[1]; r["msg"] != "past" || r["severity"] != "ERROR" {
+		t.Errorf("expected an error, got %v", r)
+	} else if remaining, _ := r[DeadlineRemainingKey].(float64); remaining >= 0 {
+		t.Errorf("expected a negative remaining time, got %v", r[DeadlineRemainingKey])
+	}
+}
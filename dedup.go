@@ -0,0 +1,205 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DedupCountKey is the key of the number of duplicates summarized by a record (see WithDedup).
+const DedupCountKey = "count"
+
+// WithDedup collapses identical records (same level, message and fields, context fields
+// included) logged within window: the first one is written as usual, and its duplicates are
+// only counted. Once window has elapsed since the first one, a single summary of the
+// duplicates is written, if there were any: the same record with their number under
+// DedupCountKey. Beyond sampling (see WithLevelSampling), this keeps every record's
+// information while writing a burst of duplicates once.
+//
+// The pending summaries are written when the backend is synced and when the logging context is
+// closed (see Close). Records at PanicLevel and FatalLevel are never deduplicated. A window that
+// isn't positive makes NewContext fail.
+func WithDedup(window time.Duration) ContextOption {
+	return func(o *contextOptions) {
+		if window <= 0 {
+			o.errs = append(o.errs, fmt.Errorf("invalid dedup window: %s", window))
+
+			return
+		}
+
+		o.dedupWindow = window
+	}
+}
+
+// dedupState counts the duplicates of the records of a logging context until their window
+// elapses.
+type dedupState struct {
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[string]*dedupRecord
+}
+
+// dedupRecord is a record written within its window, along with the number of its duplicates
+// since.
+type dedupRecord struct {
+	core   zapcore.Core
+	entry  zapcore.Entry
+	fields []zapcore.Field
+	count  int
+	timer  *time.Timer
+}
+
+func newDedupState(window time.Duration) *dedupState {
+	return &dedupState{window: window, pending: make(map[string]*dedupRecord)}
+}
+
+// add reports whether a record to be written to core is to be written now, or counts it if
+// it's a duplicate of a record written within the window.
+func (s *dedupState) add(
+	key string, core zapcore.Core, entry zapcore.Entry, fields []zap.Field,
+) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.pending[key]; ok {
+		r.count++
+		// the summary is timestamped with the last duplicate
+		r.entry.Time = entry.Time
+
+		return false
+	}
+
+	r := &dedupRecord{core: core, entry: entry, fields: slices.Clone(fields)}
+	r.timer = time.AfterFunc(s.window, func() {
+		_ = s.expire(key, r)
+	})
+	s.pending[key] = r
+
+	return true
+}
+
+// expire writes the summary of r once its window has elapsed, unless it was flushed already.
+func (s *dedupState) expire(key string, r *dedupRecord) error {
+	s.mu.Lock()
+	if s.pending[key] != r {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	delete(s.pending, key)
+	s.mu.Unlock()
+
+	return r.summarize()
+}
+
+// flush writes the pending summaries, in the order of their last duplicate.
+func (s *dedupState) flush() error {
+	s.mu.Lock()
+	records := make([]*dedupRecord, 0, len(s.pending))
+
+	for key, r := range s.pending {
+		r.timer.Stop()
+		records = append(records, r)
+		delete(s.pending, key)
+	}
+	s.mu.Unlock()
+
+	slices.SortFunc(records, func(a, b *dedupRecord) int {
+		return a.entry.Time.Compare(b.entry.Time)
+	})
+
+	var errs []error
+
+	for _, r := range records {
+		errs = append(errs, r.summarize())
+	}
+
+	return errors.Join(errs...)
+}
+
+// summarize writes the summary of the duplicates of r, if any.
+func (r *dedupRecord) summarize() error {
+	if r.count == 0 {
+		return nil
+	}
+
+	return r.core.Write(r.entry, append(r.fields, zap.Int(DedupCountKey, r.count)))
+}
+
+// dedupCore counts the duplicates of the records it writes in a dedupState rather than writing
+// them.
+type dedupCore struct {
+	zapcore.Core
+	state *dedupState
+	// context identifies the context fields given through With, which are part of the records
+	context string
+	explain zapcore.WriteSyncer // see WithExplain
+}
+
+func (c *dedupCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *dedupCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if entry.Level > zapcore.ErrorLevel {
+		return c.Core.Write(entry, fields)
+	}
+
+	key := fmt.Sprintf("%d\x00%s\x00%s\x00%s\x00%s\x00%s", entry.Level, entry.LoggerName,
+		entry.Caller, entry.Message, c.context, encodeKey(fields))
+	if !c.state.add(key, c.Core, entry, fields) {
+		explainTo(c.explain, entry.Level, entry.Message, reasonDeduped)
+
+		return nil
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{
+		Core:    c.Core.With(fields),
+		state:   c.state,
+		context: c.context + encodeKey(fields),
+		explain: c.explain,
+	}
+}
+
+func (c *dedupCore) Sync() error {
+	return errors.Join(c.state.flush(), c.Core.Sync())
+}
+
+// encodeKey returns a string identifying fields, which is the same for fields with the same
+// keys and values.
+func encodeKey(fields []zapcore.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+
+	// fmt sorts the keys of maps, so the string is stable
+	return fmt.Sprint(enc.Fields)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithDedup(t *testing.T) {
+	ctx, read := newFileContext(t, WithDedup(time.Hour))
+
+	for range 5 {
+		Info(ctx, "connection refused", WithField("host", "db1"))
+	}
+
+	Info(ctx, "connection refused", WithField("host", "db2"))
+	Warn(ctx, "connection refused", WithField("host", "db1"))
+	Info(ContextWithField(ctx, "request", 1), "connection refused", WithField("host", "db1"))
+
+	// the first occurrences are written right away
+	records := read()
+	requireRecords(t, records, 4)
+
+	for i, want := range []struct {
+		level string
+		host  string
+	}{
+		{"INFO", "db1"},
+		{"INFO", "db2"},
+		{"WARN", "db1"},
+		{"INFO", "db1"},
+	} {
+		r := records[i]
+		if r["severity"] != want.level || r["host"] != want.host || r[DedupCountKey] != nil {
+			t.Errorf("unexpected record %d: %v", i, r)
+		}
+	}
+
+	if records[3]["request"] != 1.0 {
+		t.Errorf("expected the context field, got %v", records[3])
+	}
+
+	b, _ := backendOf(ctx)
+	if err := b.Sync(); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	records = read()
+	requireRecords(t, records, 5)
+
+	if r := records[4]; r["host"] != "db1" || r["severity"] != "INFO" || r[DedupCountKey] != 4.0 {
+		t.Errorf("expected a summary of the 4 duplicates, got %v", r)
+	}
+}
+
+func TestWithDedupWindow(t *testing.T) {
+	ctx, read := newFileContext(t, WithDedup(10*time.Millisecond))
+
+	for range 3 {
+		Info(ctx, "retrying")
+	}
+
+	requireRecords(t, read(), 1)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(read()) == 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if records[1][DedupCountKey] != 2.0 {
+		t.Errorf("expected a count of 2, got %v", records[1])
+	}
+
+	// the window is over, the next occurrence is written right away
+	Info(ctx, "retrying")
+
+	requireRecords(t, read(), 3)
+}
+
+func TestWithDedupClose(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithDedup(time.Hour))
+
+	Info(ctx, "x")
+	Info(ctx, "x")
+	Info(ctx, "y")
+
+	if err := Close(ctx); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+
+	out := read()
+	if strings.Count(out, "\n") != 3 || strings.Count(out, `"count":1`) != 1 {
+		t.Errorf("expected the records and a single summary, got %s", out)
+	}
+}
+
+func TestWithDedupInvalid(t *testing.T) {
+	if _, err := NewContext(context.Background(), WithDedup(0)); err == nil {
+		t.Error("expected an error for a zero window")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"slices"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithDedupeFields makes records carry each key once: a field overrides an earlier one with
+// the same key (eg. a record field overrides a context field), so that strict JSON parsers
+// accept the output. Fields nested in different namespaces (see WithNamespace) don't collide.
+//
+// Contexts with hooks (see WithHooks) always deduplicate their fields.
+func WithDedupeFields() ContextOption {
+	return func(o *contextOptions) {
+		o.dedupe = true
+	}
+}
+
+// rewriteCore rewrites the fields of the records it writes, context fields included.
+//
+// Like hooksLogger, it keeps the context fields it is given through With rather than
+// passing them down to the wrapped core, so that they can be rewritten along with the
+// record's fields.
+type rewriteCore struct {
+	zapcore.Core
+	rewrite func([]zapcore.Field) []zapcore.Field
+	context []zapcore.Field
+}
+
+func (c *rewriteCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+// Write passes rewrite a copy of the fields, which it may modify in place.
+func (c *rewriteCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+
+	return c.Core.Write(entry, c.rewrite(append(append(all, c.context...), fields...)))
+}
+
+func (c *rewriteCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rewriteCore{
+		Core:    c.Core,
+		rewrite: c.rewrite,
+		context: append(slices.Clip(c.context), fields...),
+	}
+}
+
+// scopedKey identifies a field within its namespace.
+type scopedKey struct {
+	scope int
+	key   string
+}
+
+// dedupeFields removes fields overridden by a later field with the same key in the same
+// namespace, in place. A zap.Namespace field opens a new namespace for the fields following
+// it; its own key belongs to the enclosing one.
+func dedupeFields(fields []zapcore.Field) []zapcore.Field {
+	last := make(map[scopedKey]int, len(fields))
+	scope := 0
+
+	for i := range fields {
+		last[scopedKey{scope, fields[i].Key}] = i
+
+		if fields[i].Type == zapcore.NamespaceType {
+			scope++
+		}
+	}
+
+	if len(last) == len(fields) {
+		return fields
+	}
+
+	deduped := fields[:0]
+	scope = 0
+
+	for i := range fields {
+		if last[scopedKey{scope, fields[i].Key}] == i {
+			deduped = append(deduped, fields[i])
+		}
+
+		if fields[i].Type == zapcore.NamespaceType {
+			scope++
+		}
+	}
+
+	return deduped
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithDedupeFields(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithDedupeFields())
+	ctx = ContextWithField(ctx, "k", 1)
+
+	Info(ctx, "x", WithField("k", 2), WithNamespace("n"), WithField("k", 3))
+
+	if got, want := read(), `{"severity":"INFO","msg":"x","k":2,"n":{"k":3}}`+"\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithoutDedupeFields(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding())
+
+	Info(ContextWithField(ctx, "k", 1), "x", WithField("k", 2))
+
+	if got := read(); strings.Count(got, `"k"`) != 2 {
+		t.Errorf("expected duplicate keys without the option, got %s", got)
+	}
+}
+
+func TestDedupeFields(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		fields []zapcore.Field
+		want   []zapcore.Field
+	}{
+		{
+			name:   "no duplicates",
+			fields: []zapcore.Field{zap.Int("a", 1), zap.Int("b", 2)},
+			want:   []zapcore.Field{zap.Int("a", 1), zap.Int("b", 2)},
+		},
+		{
+			name:   "last wins",
+			fields: []zapcore.Field{zap.Int("a", 1), zap.Int("b", 2), zap.Int("a", 3)},
+			want:   []zapcore.Field{zap.Int("b", 2), zap.Int("a", 3)},
+		},
+		{
+			name: "namespaces are separate scopes",
+			fields: []zapcore.Field{
+				zap.Int("a", 1), zap.Namespace("n"), zap.Int("a", 2), zap.Int("a", 3),
+			},
+			want: []zapcore.Field{zap.Int("a", 1), zap.Namespace("n"), zap.Int("a", 3)},
+		},
+		{
+			name:   "namespace overrides a field",
+			fields: []zapcore.Field{zap.Int("n", 1), zap.Namespace("n"), zap.Int("n", 2)},
+			want:   []zapcore.Field{zap.Namespace("n"), zap.Int("n", 2)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := dedupeFields(tc.fields)
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+
+			for i := range got {
+				if !got[i].Equals(tc.want[i]) {
+					t.Errorf("expected %v at %d, got %v", tc.want[i], i, got[i])
+				}
+			}
+		})
+	}
+}
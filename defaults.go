@@ -0,0 +1,71 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithDefaultField adds a field with value under key to every record that doesn't have key
+// already, neither among its own fields nor among the logging context's (eg. a "tenant" of
+// "unknown"). Unlike context fields, it never results in duplicate keys.
+func WithDefaultField(key string, value any) ContextOption {
+	return func(o *contextOptions) {
+		if o.record.defaults == nil {
+			o.record.defaults = make(Fields)
+		}
+
+		o.record.defaults[key] = value
+	}
+}
+
+func addDefaultFields(ctx context.Context, o *options) {
+	rc, ok := ctx.Value(recordKey).(*recordConfig)
+	if !ok || len(rc.defaults) == 0 {
+		return
+	}
+
+	contextFields, _ := ctx.Value(contextFieldsKey).([]zap.Field)
+
+	for k, v := range rc.defaults {
+		if _, exists := o.fields[k]; exists || hasKey(contextFields, k) {
+			continue
+		}
+
+		if o.fields == nil {
+			o.fields = make(Fields)
+		}
+
+		o.fields[k] = v
+	}
+}
+
+// hasKey reports whether fields has a top-level field with key.
+func hasKey(fields []zap.Field, key string) bool {
+	for i := range fields {
+		if fields[i].Key == key {
+			return true
+		}
+
+		if fields[i].Type == zapcore.NamespaceType {
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithDefaultField(t *testing.T) {
+	ctx, read := newFileContext(t, WithDefaultField("tenant", "unknown"))
+
+	Info(ctx, "default")
+	Info(ctx, "record", WithField("tenant", "t1"))
+	Info(ContextWithField(ctx, "tenant", "t2"), "context")
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	for i, want := range []string{"unknown", "t1", "t2"} {
+		if records[i]["tenant"] != want {
+			t.Errorf("expected tenant %q, got %v", want, records[i])
+		}
+	}
+}
+
+func TestWithDefaultFieldNoDuplicates(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithDefaultField("tenant", "unknown"))
+
+	Info(ContextWithField(ctx, "tenant", "t1"), "x")
+
+	if got := read(); strings.Count(got, `"tenant"`) != 1 {
+		t.Errorf("expected a single tenant, got %s", got)
+	}
+}
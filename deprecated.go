@@ -0,0 +1,66 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+const (
+	// DeprecatedKey is the key that marks deprecation warnings (see Deprecated).
+	DeprecatedKey = "deprecated"
+	// CallerKey is the key that has the caller's location (file:line) as value.
+	CallerKey = "caller"
+)
+
+// Deprecated logs a deprecation warning at WarnLevel, once per call site and logging context.
+// It is meant to be called from within deprecated functions: the call site is the location
+// that called the deprecated function, which is reported under CallerKey (in the short form
+// of WithCaller if enabled).
+//
+//	func OldAPI(ctx context.Context) {
+//		clog.Deprecated(ctx, "OldAPI is deprecated, use NewAPI")
+//		...
+//	}
+func Deprecated(ctx context.Context, msg string, opts ...Option) {
+	rc, ok := ctx.Value(recordKey).(*recordConfig)
+	if !ok || !WarnEnabled(ctx) {
+		return
+	}
+
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return
+	}
+
+	site := fmt.Sprintf("%s:%d", file, line)
+
+	if _, seen := rc.deprecations.LoadOrStore(site, struct{}{}); seen {
+		return
+	}
+
+	prefix := []Option{WithField(DeprecatedKey, true)}
+
+	if rc.caller {
+		// the record is annotated with the call site rather than this function's caller
+		ctx = skipCallers(ctx, 2)
+	} else {
+		prefix = append(prefix, WithField(CallerKey, site))
+	}
+
+	Warn(ctx, msg, append(prefix, opts...)...)
+}
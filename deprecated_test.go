@@ -0,0 +1,57 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func deprecatedFunc(ctx context.Context) {
+	Deprecated(ctx, "deprecatedFunc is deprecated")
+}
+
+func TestDeprecated(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	for range 3 {
+		deprecatedFunc(ctx) // first site
+	}
+
+	deprecatedFunc(ctx) // second site
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	for _, r := range records {
+		caller, _ := r[CallerKey].(string)
+
+		if r["severity"] != "WARN" || r[DeprecatedKey] != true ||
+			!strings.Contains(caller, "deprecated_test.go:") {
+			t.Errorf("unexpected record %v", r)
+		}
+	}
+
+	if records[0][CallerKey] == records[1][CallerKey] {
+		t.Error("expected distinct call sites")
+	}
+
+	other, readOther := newFileContext(t)
+
+	deprecatedFunc(other)
+
+	requireRecords(t, readOther(), 1)
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// ErrorChainKey is the key that has as value the messages of the chain of wrapped errors
+	// (see WithErrorChain).
+	ErrorChainKey = "error_chain"
+	// ErrorStackKey is the key that has as value the stack trace carried by an error (see
+	// WithErrorChain).
+	ErrorStackKey = "error_stack"
+)
+
+// WithErrorChain makes errors logged with WithError also emit the messages of every error
+// in their chain (as unwrapped with errors.Unwrap or Unwrap() []error) under ErrorChainKey.
+//
+// If an error in the chain has a StackTrace method (like those created with
+// github.com/pkg/errors), the innermost stack trace is emitted under ErrorStackKey.
+func WithErrorChain() ContextOption {
+	return func(o *contextOptions) {
+		o.record.errorChain = true
+	}
+}
+
+// LogError logs msg at ErrorLevel with err attached (see WithError) and returns err, to log
+// and return an error in one call:
+//
+//	if err := db.Write(ctx, row); err != nil {
+//		return clog.LogError(ctx, "db write failed", err)
+//	}
+//
+// Nothing is logged if err is nil. With WithCaller, the record is annotated with the location
+// of the call to LogError.
+func LogError(ctx context.Context, msg string, err error, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+
+	Error(skipCallers(ctx, 1), msg, append([]Option{WithError(err)}, opts...)...)
+
+	return err
+}
+
+// LogWrappedError is like LogError but returns err wrapped with msg ("msg: err"), so that the
+// error carries the context it was logged with.
+func LogWrappedError(ctx context.Context, msg string, err error, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+
+	Error(skipCallers(ctx, 1), msg, append([]Option{WithError(err)}, opts...)...)
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// WithErrorFormatter makes errors logged with WithError (or WithErrors) emit the value returned
+// by fn instead of their message, eg. a struct or a map with an error code and a cause.
+func WithErrorFormatter(fn func(error) any) ContextOption {
+	return func(o *contextOptions) {
+		o.record.errorFormatter = fn
+	}
+}
+
+// errorFields returns the fields for the errors of a log record.
+func errorFields(ctx context.Context, errs []error) []zap.Field {
+	var (
+		field zap.Field
+		err   = errs[0]
+		key   = errorKeyOf(ctx)
+	)
+
+	rc, _ := ctx.Value(recordKey).(*recordConfig)
+
+	switch {
+	case rc != nil && rc.errorFormatter != nil:
+		field = zap.Any(key, formatErrors(rc.errorFormatter, errs))
+	case len(errs) == 1:
+		field = zap.NamedError(key, err)
+	default:
+		field = zap.Array(key, errorArray(errs))
+	}
+
+	if len(errs) > 1 {
+		err = errors.Join(errs...)
+	}
+
+	if rc != nil && rc.errorChain {
+		return append([]zap.Field{field}, errorChainFields(err)...)
+	}
+
+	return []zap.Field{field}
+}
+
+// formatErrors returns the value of a single error formatted with fn, or the values of
+// several errors as a slice.
+func formatErrors(fn func(error) any, errs []error) any {
+	if len(errs) == 1 {
+		return fn(errs[0])
+	}
+
+	values := make([]any, len(errs))
+
+	for i, err := range errs {
+		values[i] = fn(err)
+	}
+
+	return values
+}
+
+// errorArray marshals errors as an array of their messages.
+type errorArray []error
+
+func (errs errorArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, err := range errs {
+		enc.AppendString(err.Error())
+	}
+
+	return nil
+}
+
+func errorChainFields(err error) []zap.Field {
+	var (
+		chain []string
+		stack string
+	)
+
+	walkErrors(err, func(e error) {
+		chain = append(chain, e.Error())
+
+		if s, ok := stackTrace(e); ok {
+			stack = s
+		}
+	})
+
+	fields := []zap.Field{zap.Strings(ErrorChainKey, chain)}
+
+	if stack != "" {
+		fields = append(fields, zap.String(ErrorStackKey, stack))
+	}
+
+	return fields
+}
+
+// walkErrors visits err and the errors it wraps depth-first.
+func walkErrors(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+
+	fn(err)
+
+	if u, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range u.Unwrap() {
+			walkErrors(e, fn)
+		}
+
+		return
+	}
+
+	walkErrors(errors.Unwrap(err), fn)
+}
+
+// stackTrace returns the stack trace of errors that have a StackTrace method. Since there
+// is no standard signature, any method without arguments returning a single value is
+// accepted and its result is formatted with "%+v" (as github.com/pkg/errors expects).
+func stackTrace(err error) (string, bool) {
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return "", false
+	}
+
+	return fmt.Sprintf("%+v", m.Call(nil)[0].Interface()), true
+}
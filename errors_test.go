@@ -0,0 +1,218 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type stackError struct {
+	msg string
+}
+
+func (e *stackError) Error() string { return e.msg }
+
+func (*stackError) StackTrace() fmt.Stringer { return stackString("main.go:42") }
+
+type stackString string
+
+func (s stackString) String() string { return string(s) }
+
+func TestWithErrorChain(t *testing.T) {
+	ctx, read := newFileContext(t, WithErrorChain())
+
+	root := &stackError{msg: "root"}
+	err := fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", root))
+
+	Error(ctx, "failed", WithError(err))
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	chain, _ := records[0][ErrorChainKey].([]any)
+	want := []string{"outer: middle: root", "middle: root", "root"}
+
+	if len(chain) != len(want) {
+		t.Fatalf("unexpected chain %v", chain)
+	}
+
+	for i := range want {
+		if chain[i] != want[i] {
+			t.Errorf("expected chain[%d]=%q, got %q", i, want[i], chain[i])
+		}
+	}
+
+	if records[0][ErrorStackKey] != "main.go:42" {
+		t.Errorf("unexpected stack %v", records[0][ErrorStackKey])
+	}
+
+	if records[0][DefaultErrorKey] != err.Error() {
+		t.Errorf("unexpected error %v", records[0][DefaultErrorKey])
+	}
+}
+
+func TestWithErrorChainJoined(t *testing.T) {
+	ctx, read := newFileContext(t, WithErrorChain())
+
+	Error(ctx, "failed", WithError(errors.Join(errors.New("a"), errors.New("b"))))
+
+	chain, _ := read()[0][ErrorChainKey].([]any)
+	if len(chain) != 3 || chain[1] != "a" || chain[2] != "b" {
+		t.Errorf("unexpected chain %v", chain)
+	}
+}
+
+func TestWithoutErrorChain(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Error(ctx, "failed", WithError(fmt.Errorf("outer: %w", errors.New("inner"))))
+
+	r := read()[0]
+
+	if _, ok := r[ErrorChainKey]; ok {
+		t.Errorf("expected no chain by default: %v", r)
+	}
+
+	if !strings.HasPrefix(r[DefaultErrorKey].(string), "outer") {
+		t.Errorf("unexpected error %v", r)
+	}
+}
+
+func TestWithErrors(t *testing.T) {
+	ctx, read := newFileContext(t, WithMaxFields(1))
+
+	Error(ctx, "batch failed",
+		WithError(errors.New("first")),
+		WithErrors(errors.New("second"), nil, errors.New("third")),
+		WithField("a", 1),
+		WithField("b", 2),
+	)
+
+	r := read()[0]
+
+	errs, _ := r[DefaultErrorKey].([]any)
+	if len(errs) != 3 || errs[0] != "first" || errs[1] != "second" || errs[2] != "third" {
+		t.Errorf("unexpected errors %v", r[DefaultErrorKey])
+	}
+}
+
+func TestWithErrorsSingle(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Error(ctx, "failed", WithErrors(errors.New("only")))
+
+	if got := read()[0][DefaultErrorKey]; got != "only" {
+		t.Errorf("expected a single error string, got %v", got)
+	}
+}
+
+func TestWithErrorAccumulates(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	first, second := errors.New("first"), errors.New("second")
+
+	Error(ctx, "failed", WithError(first), WithError(nil), WithError(second))
+
+	if got := read()[0][DefaultErrorKey]; got != "first\nsecond" {
+		t.Errorf("expected both errors, got %q", got)
+	}
+}
+
+type codedError struct {
+	code int
+	msg  string
+}
+
+func (e *codedError) Error() string { return e.msg }
+
+func TestWithErrorFormatter(t *testing.T) {
+	ctx, read := newFileContext(t, WithErrorFormatter(func(err error) any {
+		var coded *codedError
+		if errors.As(err, &coded) {
+			return map[string]any{"code": coded.code, "msg": coded.msg}
+		}
+
+		return err.Error()
+	}))
+
+	Error(ctx, "failed", WithError(fmt.Errorf("wrapped: %w", &codedError{code: 42, msg: "boom"})))
+	Error(ctx, "failed", WithErrors(&codedError{code: 7, msg: "a"}, errors.New("b")))
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if got := fmt.Sprint(records[0][DefaultErrorKey]); got != "map[code:42 msg:boom]" {
+		t.Errorf("unexpected error: %s", got)
+	}
+
+	if got := fmt.Sprint(records[1][DefaultErrorKey]); got != "[map[code:7 msg:a] b]" {
+		t.Errorf("unexpected errors: %s", got)
+	}
+}
+
+func TestLogError(t *testing.T) {
+	ctx, read := newFileContext(t, WithCaller())
+
+	if err := LogError(ctx, "none", nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	cause := errors.New("boom")
+
+	err := LogError(ctx, "failed", cause, WithField("attempt", 2))
+	line := previousLine()
+
+	if err != cause {
+		t.Errorf("expected the same error, got %v", err)
+	}
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	r := records[0]
+	if r["msg"] != "failed" || r["severity"] != "ERROR" || r[DefaultErrorKey] != "boom" ||
+		r["attempt"] != float64(2) {
+		t.Errorf("unexpected record: %v", r)
+	}
+
+	if r["caller"] != line {
+		t.Errorf("expected caller %s, got %v", line, r["caller"])
+	}
+}
+
+func TestLogWrappedError(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	if err := LogWrappedError(ctx, "none", nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	cause := errors.New("boom")
+
+	err := LogWrappedError(ctx, "db write failed", cause)
+	if !errors.Is(err, cause) || err.Error() != "db write failed: boom" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if r := records[0]; r["msg"] != "db write failed" || r[DefaultErrorKey] != "boom" {
+		t.Errorf("unexpected record: %v", r)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// explain writes the reason why a log record was suppressed to the context's explain
+// writer, if one was configured with WithExplain.
+func explain(ctx context.Context, level Level, msg, reason string) {
+	w, ok := ctx.Value(explainKey).(zapcore.WriteSyncer)
+	if !ok {
+		return
+	}
+
+	explainTo(w, zapcore.Level(level), msg, reason)
+}
+
+// explainTo is like explain for the cores suppressing entries, given the explain writer, if
+// any (w is then nil).
+func explainTo(w zapcore.WriteSyncer, level zapcore.Level, msg, reason string) {
+	if w == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "suppressed %s %q: %s\n", level.CapitalString(), msg, reason)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithExplain(t *testing.T) {
+	var buf bytes.Buffer
+
+	ctx, read := newFileContext(t, WithExplain(&buf))
+
+	Debug(ctx, "hidden")
+	Info(ctx, "shown")
+
+	if got, want := buf.String(), "suppressed DEBUG \"hidden\": below level\n"; got != want {
+		t.Errorf("expected explanation %q, got %q", want, got)
+	}
+
+	requireRecords(t, read(), 1)
+}
+
+func TestWithExplainSuppressedByCores(t *testing.T) {
+	var buf bytes.Buffer
+
+	ctx, read := newFileContext(t,
+		WithExplain(&buf),
+		WithSamplingHook(func(entry zapcore.Entry) zapcore.SamplingDecision {
+			if entry.Message == "noisy" {
+				return zapcore.LogDropped
+			}
+
+			return zapcore.LogSampled
+		}),
+		WithLevelSampling(InfoLevel, 1, 0),
+		WithDedup(time.Hour),
+	)
+
+	Info(ctx, "noisy")
+	Info(ctx, "sampled", WithField("n", 1))
+	Info(ctx, "sampled", WithField("n", 2))
+	Warn(ctx, "repeated")
+	Warn(ctx, "repeated")
+
+	want := "suppressed INFO \"noisy\": sampled\n" +
+		"suppressed INFO \"sampled\": sampled\n" +
+		"suppressed WARN \"repeated\": deduped\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected explanations %q, got %q", want, got)
+	}
+
+	requireRecords(t, read(), 2)
+}
+
+func TestWithoutExplain(t *testing.T) {
+	// must not panic without an explain writer
+	explain(context.Background(), DebugLevel, "msg", reasonBelowLevel)
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap/zapcore"
+
+// WithGCPSeverity lays out records the way Google Cloud Logging expects them: the message
+// under "message", the time under "timestamp" and the level under "severity" as one of GCP's
+// severities ("DEBUG", "INFO", "WARNING", "ERROR" or "CRITICAL"). It's meant to be used with
+// WithJSONEncoding. Key options given after it (eg. WithLevelKey) override its keys.
+func WithGCPSeverity() ContextOption {
+	return func(o *contextOptions) {
+		o.levelKey = DefaultLevelKey
+		o.msgKey = "message"
+		o.timeKey = "timestamp"
+		o.encodeLevel = gcpSeverityEncoder
+	}
+}
+
+// gcpSeverityEncoder encodes levels as Google Cloud Logging severities.
+func gcpSeverityEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch level {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel, zapcore.PanicLevel, zapcore.FatalLevel:
+		enc.AppendString("CRITICAL")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
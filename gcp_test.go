@@ -0,0 +1,89 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithGCPSeverity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ctx, err := NewContext(context.Background(),
+		WithJSONEncoding(),
+		WithGCPSeverity(),
+		WithClock(func() time.Time { return now }),
+		WithRotatingFile(path, 0, 0, 0),
+	)
+	if err != nil {
+		t.Fatalf("failed to create logging context: %v", err)
+	}
+
+	t.Cleanup(func() { _ = Close(ctx) })
+
+	Warn(ctx, "x", WithField("a", 1))
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+
+	want := map[string]any{
+		"severity":  "WARNING",
+		"message":   "x",
+		"timestamp": "2024-01-02T03:04:05Z",
+		"a":         1.0,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestGCPSeverityEncoder(t *testing.T) {
+	ctx, read := newFileContext(t, WithGCPSeverity(), WithLevel(DebugLevel))
+
+	Debug(ctx, "x")
+	Info(ctx, "x")
+	Warn(ctx, "x")
+	Error(ctx, "x")
+
+	func() {
+		defer func() { _ = recover() }()
+
+		Panic(ctx, "x")
+	}()
+
+	records := read()
+	requireRecords(t, records, 5)
+
+	for i, want := range []string{"DEBUG", "INFO", "WARNING", "ERROR", "CRITICAL"} {
+		if records[i]["severity"] != want {
+			t.Errorf("expected %s, got %v", want, records[i])
+		}
+	}
+}
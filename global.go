@@ -0,0 +1,77 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"sync"
+)
+
+var globalFieldHooks struct {
+	mu    sync.RWMutex
+	hooks []func(context.Context) Fields
+}
+
+// RegisterGlobalFieldHook registers fn to be invoked for every log record of every logging
+// context created afterwards with Context or NewContext. The fields it returns (given the
+// context passed to the logging function) are added to the record, unless the record
+// already has a field with the same key.
+//
+// It is meant for platform-wide fields that must be present without each service opting in.
+func RegisterGlobalFieldHook(fn func(context.Context) Fields) {
+	globalFieldHooks.mu.Lock()
+	defer globalFieldHooks.mu.Unlock()
+
+	globalFieldHooks.hooks = append(globalFieldHooks.hooks, fn)
+}
+
+// ClearGlobalFieldHooks unregisters all hooks registered with RegisterGlobalFieldHook. Logging
+// contexts created before it is called keep their hooks.
+func ClearGlobalFieldHooks() {
+	globalFieldHooks.mu.Lock()
+	defer globalFieldHooks.mu.Unlock()
+
+	globalFieldHooks.hooks = nil
+}
+
+func registeredGlobalFieldHooks() []func(context.Context) Fields {
+	globalFieldHooks.mu.RLock()
+	defer globalFieldHooks.mu.RUnlock()
+
+	return append([]func(context.Context) Fields(nil), globalFieldHooks.hooks...)
+}
+
+// addExtractedFields adds the fields returned by the context's field extractors to o,
+// without overriding the record's own fields.
+func addExtractedFields(ctx context.Context, o *options) {
+	rc, ok := ctx.Value(recordKey).(*recordConfig)
+	if !ok {
+		return
+	}
+
+	for _, extract := range rc.extractors {
+		for k, v := range extract(ctx) {
+			if _, exists := o.fields[k]; exists {
+				continue
+			}
+
+			if o.fields == nil {
+				o.fields = make(Fields)
+			}
+
+			o.fields[k] = v
+		}
+	}
+}
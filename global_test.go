@@ -0,0 +1,66 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestRegisterGlobalFieldHook(t *testing.T) {
+	t.Cleanup(ClearGlobalFieldHooks)
+
+	before, readBefore := newFileContext(t)
+
+	RegisterGlobalFieldHook(func(ctx context.Context) Fields {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+
+		return Fields{"platform": "acme", "tenant": tenant}
+	})
+
+	ctx, read := newFileContext(t)
+	ctx = context.WithValue(ctx, tenantKey{}, "t1")
+
+	Info(ctx, "x")
+	Info(ctx, "y", WithField("platform", "override"))
+	Info(before, "z")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if records[0]["platform"] != "acme" || records[0]["tenant"] != "t1" {
+		t.Errorf("expected global fields, got %v", records[0])
+	}
+
+	if records[1]["platform"] != "override" {
+		t.Errorf("expected the record's field to win, got %v", records[1])
+	}
+
+	if _, ok := readBefore()[0]["platform"]; ok {
+		t.Error("contexts created before registration must not be affected")
+	}
+
+	ClearGlobalFieldHooks()
+
+	after, readAfter := newFileContext(t)
+
+	Info(after, "x")
+
+	if _, ok := readAfter()[0]["platform"]; ok {
+		t.Error("expected no global fields after clearing")
+	}
+}
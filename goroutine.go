@@ -0,0 +1,58 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+)
+
+// GoroutineKey is the key that has as value the id of the goroutine that logged the record
+// (see WithGoroutineID).
+const GoroutineKey = "goroutine"
+
+// WithGoroutineID adds the id of the goroutine that logged the record under "goroutine", eg. to
+// correlate the records of a goroutine while debugging a deadlock. The ids are those shown in
+// panics and goroutine dumps.
+//
+// Go doesn't expose goroutine ids: the id is parsed from the header of the goroutine's stack
+// trace ("goroutine 42 [running]:"), which costs a few microseconds for every record that is
+// written; disabled records cost nothing extra. Don't rely on the ids for anything but
+// debugging, the runtime reuses them.
+func WithGoroutineID() ContextOption {
+	return func(o *contextOptions) {
+		o.record.extractors = append(o.record.extractors, func(context.Context) Fields {
+			return Fields{GoroutineKey: goroutineID()}
+		})
+	}
+}
+
+// goroutineID returns the id of the current goroutine, or 0 if it can't be found.
+func goroutineID() uint64 {
+	var buf [64]byte
+
+	// the header is "goroutine <id> [<status>]:"
+	stack := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+
+	if i := bytes.IndexByte(stack, ' '); i > 0 {
+		if id, err := strconv.ParseUint(string(stack[:i]), 10, 64); err == nil {
+			return id
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestWithGoroutineID(t *testing.T) {
+	ctx, read := newFileContext(t, WithGoroutineID())
+
+	Info(ctx, "main")
+	Debug(ctx, "disabled")
+
+	var wg sync.WaitGroup
+
+	for range 3 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			Info(ctx, "worker")
+		}()
+	}
+
+	wg.Wait()
+
+	records := read()
+	requireRecords(t, records, 4)
+
+	ids := make(map[float64]bool)
+
+	for _, r := range records {
+		id, _ := r[GoroutineKey].(float64)
+		if id <= 0 {
+			t.Fatalf("expected a goroutine id, got %v", r)
+		}
+
+		ids[id] = true
+	}
+
+	if len(ids) != 4 {
+		t.Errorf("expected 4 distinct goroutine ids, got %v", ids)
+	}
+}
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	if id == 0 {
+		t.Fatal("expected a goroutine id")
+	}
+
+	if again := goroutineID(); again != id {
+		t.Errorf("expected the same id in the same goroutine, got %d and %d", id, again)
+	}
+
+	other := make(chan uint64)
+	go func() { other <- goroutineID() }()
+
+	if got := <-other; got == id || got == 0 {
+		t.Errorf("expected another id in another goroutine, got %d", got)
+	}
+}
@@ -14,38 +14,81 @@
 
 package clog
 
-import "go.uber.org/zap/zapcore"
+import (
+	"context"
+	"slices"
 
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// hooksLogger invokes hooks with every entry and its fields just before writing it.
+//
+// The context fields it is given through With are kept rather than passed down to the wrapped
+// core, so that they are written along with the record's fields: a record field overrides a
+// context field with the same key (last wins), and the hooks see exactly the fields written.
 type hooksLogger struct {
 	zapcore.Core
 	hooks   []func(zapcore.Entry, []zapcore.Field)
 	context []zapcore.Field // https://github.com/terminalstream/clog/issues/3
+	// inherited are the fields the wrapped core already had when the hooks were registered;
+	// the hooks see them but they are not written again
+	inherited []zapcore.Field
 }
 
 func (c *hooksLogger) Check(
 	entry zapcore.Entry, checked *zapcore.CheckedEntry,
 ) *zapcore.CheckedEntry {
-	if c.Enabled(entry.Level) {
-		return checked.AddCore(entry, c)
-	}
-
-	return checked
+	return checkEnabled(c, entry, checked)
 }
 
 func (c *hooksLogger) Write(entry zapcore.Entry, fields []zapcore.Field) error {
-	allFields := append(c.context, fields...)
+	written := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	written = dedupeFields(append(append(written, c.context...), fields...))
+
+	seen := written
+
+	if len(c.inherited) > 0 {
+		seen = make([]zapcore.Field, 0, len(c.inherited)+len(written))
+		seen = dedupeFields(append(append(seen, c.inherited...), written...))
+	}
 
 	for i := range c.hooks {
-		c.hooks[i](entry, allFields)
+		c.hooks[i](entry, seen)
 	}
 
-	return c.Core.Write(entry, fields)
+	return c.Core.Write(entry, written)
 }
 
 func (c *hooksLogger) With(fields []zapcore.Field) zapcore.Core {
 	return &hooksLogger{
-		Core:    c.Core.With(fields),
-		hooks:   c.hooks,
-		context: append(c.context, fields...),
+		Core:      c.Core,
+		hooks:     c.hooks,
+		context:   append(slices.Clip(c.context), fields...),
+		inherited: c.inherited,
 	}
 }
+
+// ContextWithEntryCallback returns a new logging context derived from parent that invokes cb
+// just before each log entry is written, like the hooks registered with WithHooks. This allows
+// registering hooks on an existing logging context (eg. by plugins loaded later); cb only
+// sees entries logged after it is registered, and only through the returned context and
+// contexts derived from it.
+//
+// If parent is not a logging context, or doesn't write with zap (see WithBackend), then parent
+// is returned as-is.
+func ContextWithEntryCallback(
+	parent context.Context, cb func(zapcore.Entry, []zapcore.Field),
+) context.Context {
+	ctx, _ := withZapBackend(parent, func(b *zapBackend, inherited []zap.Field) *zapBackend {
+		return b.withOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &hooksLogger{
+				Core:      core,
+				hooks:     []func(zapcore.Entry, []zapcore.Field){cb},
+				inherited: slices.Clip(inherited),
+			}
+		}))
+	})
+
+	return ctx
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type recordedEntry struct {
+	msg    string
+	fields map[string]any
+}
+
+// recorder returns a hook that records the entries it sees.
+func recorder() (func(zapcore.Entry, []zapcore.Field), *[]recordedEntry) {
+	var entries []recordedEntry
+
+	return func(entry zapcore.Entry, fields []zapcore.Field) {
+		enc := zapcore.NewMapObjectEncoder()
+
+		for _, f := range fields {
+			f.AddTo(enc)
+		}
+
+		entries = append(entries, recordedEntry{msg: entry.Message, fields: enc.Fields})
+	}, &entries
+}
+
+func TestWithHooks(t *testing.T) {
+	hook, entries := recorder()
+
+	ctx, _ := newFileContext(t, WithHooks(hook))
+
+	Info(ContextWithField(ctx, "a", 1), "x", WithField("b", 2))
+
+	if len(*entries) != 1 {
+		t.Fatalf("expected 1 entry, got %v", *entries)
+	}
+
+	if got := (*entries)[0].fields; got["a"] != int64(1) || got["b"] != int64(2) {
+		t.Errorf("expected context and record fields, got %v", got)
+	}
+}
+
+func TestContextWithEntryCallback(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	ctx = ContextWithField(ctx, "a", 1)
+
+	Info(ctx, "before")
+
+	hook, entries := recorder()
+
+	hooked := ContextWithEntryCallback(ctx, hook)
+
+	Info(ContextWithField(hooked, "b", 2), "after")
+	Info(ctx, "unhooked")
+
+	if len(*entries) != 1 || (*entries)[0].msg != "after" {
+		t.Fatalf("expected only the entry logged after registration, got %v", *entries)
+	}
+
+	if got := (*entries)[0].fields; got["a"] != int64(1) || got["b"] != int64(2) {
+		t.Errorf("expected inherited fields, got %v", got)
+	}
+
+	requireRecords(t, read(), 3)
+}
+
+func TestHooksLoggerSiblingsDoNotShareFields(t *testing.T) {
+	hook, entries := recorder()
+
+	parent := &hooksLogger{
+		Core:    zapcore.NewNopCore(),
+		hooks:   []func(zapcore.Entry, []zapcore.Field){hook},
+		context: make([]zapcore.Field, 0, 8), // spare capacity invites aliasing
+	}
+
+	a := parent.With([]zapcore.Field{zap.String("sibling", "a")})
+	b := parent.With([]zapcore.Field{zap.String("sibling", "b")})
+
+	if err := a.Write(zapcore.Entry{Message: "a"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := b.Write(zapcore.Entry{Message: "b"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, want := range []string{"a", "b"} {
+		if got := (*entries)[i].fields["sibling"]; got != want {
+			t.Errorf("expected sibling %s to see its own field, got %v", want, got)
+		}
+	}
+}
+
+func TestWithHooksRecordFieldOverridesContextField(t *testing.T) {
+	hook, entries := recorder()
+
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithHooks(hook))
+
+	Info(ContextWithField(ctx, "k", 1), "x", WithField("k", 2))
+
+	if got := read(); strings.Count(got, `"k"`) != 1 || !strings.Contains(got, `"k":2`) {
+		t.Errorf("expected a single k=2, got %s", got)
+	}
+
+	if got := (*entries)[0].fields["k"]; got != int64(2) {
+		t.Errorf("expected the hook to see k=2, got %v", got)
+	}
+}
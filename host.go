@@ -0,0 +1,50 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// HostKey is the default key that has the host name as value (see WithHostInfo).
+	HostKey = "host"
+	// PIDKey is the default key that has the process ID as value.
+	PIDKey = "pid"
+)
+
+// hostname is resolved once per process.
+var hostname = sync.OnceValues(os.Hostname)
+
+// WithHostInfo attaches "host" and "pid" fields with the host name and process ID to every
+// log record, to correlate logs across instances. The host name is resolved once per process;
+// if that fails then the "host" field is omitted.
+func WithHostInfo() ContextOption {
+	return WithHostInfoKeys(HostKey, PIDKey)
+}
+
+// WithHostInfoKeys is like WithHostInfo but with the given keys.
+func WithHostInfoKeys(hostKey, pidKey string) ContextOption {
+	return func(o *contextOptions) {
+		if host, err := hostname(); err == nil {
+			o.fields = append(o.fields, zap.String(hostKey, host))
+		}
+
+		o.fields = append(o.fields, zap.Int(pidKey, os.Getpid()))
+	}
+}
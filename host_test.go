@@ -0,0 +1,47 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWithHostInfo(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("no host name: %v", err)
+	}
+
+	ctx, read := newFileContext(t, WithHostInfo())
+
+	Info(ctx, "x")
+
+	if r := read()[0]; r[HostKey] != host || r[PIDKey] != float64(os.Getpid()) {
+		t.Errorf("expected host %q and pid %d, got %v", host, os.Getpid(), r)
+	}
+}
+
+func TestWithHostInfoKeys(t *testing.T) {
+	ctx, read := newFileContext(t, WithHostInfoKeys("hostname", "process_id"))
+
+	Info(ctx, "x")
+
+	r := read()[0]
+
+	if _, ok := r[PIDKey]; ok || r["process_id"] != float64(os.Getpid()) {
+		t.Errorf("expected the pid under the given key, got %v", r)
+	}
+}
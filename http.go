@@ -0,0 +1,102 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// RequestIDKey is the key that has the request ID as value (see Middleware).
+	RequestIDKey = "request_id"
+	// RequestIDHeader is the header the request ID is propagated with.
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength bounds the length of propagated request IDs, which are untrusted.
+	maxRequestIDLength = 128
+)
+
+// HTTPKey is the key that has the fields describing an HTTP request as value (see
+// WithRequest).
+const HTTPKey = "http"
+
+// WithRequest adds the method, path, remote address and user agent of r to the log record,
+// grouped under "http": {"http": {"method": "GET", "path": "/", ...}}. Nothing else is taken
+// from r: the query string and headers such as Authorization or Cookie are never logged.
+//
+// Unlike WithNamespace it doesn't affect the options that follow it.
+func WithRequest(r *http.Request) Option {
+	return WithField(HTTPKey, httpRequest{
+		method:     r.Method,
+		path:       r.URL.Path,
+		remoteAddr: r.RemoteAddr,
+		userAgent:  r.UserAgent(),
+	})
+}
+
+// httpRequest holds the loggable attributes of an HTTP request.
+type httpRequest struct {
+	method     string
+	path       string
+	remoteAddr string
+	userAgent  string
+}
+
+func (r httpRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("method", r.method)
+	enc.AddString("path", r.path)
+	enc.AddString("remote_addr", r.remoteAddr)
+	enc.AddString("user_agent", r.userAgent)
+
+	return nil
+}
+
+// Middleware returns an HTTP middleware that stores a logging context derived from base on
+// every request's context, with the request ID under "request_id". The ID is taken from the
+// X-Request-ID header or, if missing (or longer than 128 bytes), generated. Handlers log with
+// the request's context:
+//
+//	clog.Info(r.Context(), "order created")
+func Middleware(base context.Context) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" || len(id) > maxRequestIDLength {
+				id = newRequestID()
+			}
+
+			ctx := ContextWithField(CopyContext(r.Context(), base), RequestIDKey, id)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// newRequestID returns a random ID formatted like a version 4 UUID.
+func newRequestID() string {
+	var b [16]byte
+
+	_, _ = rand.Read(b[:]) // never returns an error
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWithRequest(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(DebugLevel))
+
+	r := httptest.NewRequest("POST", "/orders?token=secret", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("User-Agent", "test/1.0")
+	r.Header.Set("Authorization", "Bearer secret")
+
+	Debug(ctx, "x", WithRequest(r), WithField("a", 1))
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	want := map[string]any{
+		"method":      "POST",
+		"path":        "/orders",
+		"remote_addr": "10.0.0.1:1234",
+		"user_agent":  "test/1.0",
+	}
+
+	if got := records[0][HTTPKey]; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if records[0]["a"] != 1.0 {
+		t.Errorf("expected the following options at the top level, got %v", records[0])
+	}
+
+}
+
+func TestWithRequestOmitsSecrets(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding())
+
+	r := httptest.NewRequest("GET", "/?token=secret", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Set("Cookie", "session=secret")
+
+	Info(ctx, "x", WithRequest(r))
+
+	if got := read(); strings.Contains(got, "secret") {
+		t.Errorf("expected no secrets, got %s", got)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	base, read := newFileContext(t)
+
+	handler := Middleware(base)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		Info(r.Context(), "handled")
+	}))
+
+	for _, id := range []string{"abc", "", strings.Repeat("x", 129)} {
+		r := httptest.NewRequest("GET", "/", nil)
+		if id != "" {
+			r.Header.Set(RequestIDHeader, id)
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	if records[0][RequestIDKey] != "abc" {
+		t.Errorf("expected the propagated request ID, got %v", records[0])
+	}
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	for _, r := range records[1:] {
+		if id, _ := r[RequestIDKey].(string); !uuid.MatchString(id) {
+			t.Errorf("expected a generated request ID, got %v", r)
+		}
+	}
+
+	if records[1][RequestIDKey] == records[2][RequestIDKey] {
+		t.Error("expected unique request IDs")
+	}
+}
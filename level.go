@@ -0,0 +1,111 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ContextWithIndependentLevel returns a new logging context derived from parent with its own
+// level, initially set to level. SetLevel on the returned context (or on contexts derived from
+// it) doesn't affect parent, and vice versa; eg. one subsystem can log at DebugLevel while the
+// rest stays at InfoLevel.
+//
+// If parent is not a logging context, or doesn't write with zap (see WithBackend), then parent
+// is returned as-is.
+func ContextWithIndependentLevel(parent context.Context, level Level) context.Context {
+	atomic := zap.NewAtomicLevelAt(zapcore.Level(level))
+
+	ctx, ok := withZapBackend(parent, func(b *zapBackend, _ []zap.Field) *zapBackend {
+		logger := b.logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &levelCore{Core: core, level: atomic}
+		}))
+
+		return &zapBackend{logger: logger, level: &atomic}
+	})
+	if !ok {
+		return parent
+	}
+
+	// the level change callbacks are about parent's level
+	return context.WithValue(ctx, levelChangeKey, nil)
+}
+
+// levelCore replaces the level of the wrapped core. Entries enabled by its level are written
+// to the wrapped core regardless of the wrapped core's own level.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.level)
+}
+
+func (c *levelCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{
+		Core:  c.Core.With(fields),
+		level: c.level,
+	}
+}
+
+// WithLevelChangeCallback registers fn to be invoked whenever the logging context's level is
+// changed with SetLevel (or WithTemporaryLevel), eg. to count level changes. fn is invoked
+// with the previous and the new level, in the goroutine that changed the level, once the
+// change is done. It isn't invoked if the level doesn't actually change, nor for contexts
+// with an independent level (see ContextWithIndependentLevel).
+func WithLevelChangeCallback(fn func(old, new Level)) ContextOption {
+	return func(o *contextOptions) {
+		o.levelCallbacks = append(o.levelCallbacks, fn)
+	}
+}
+
+// levelNotifier invokes the level change callbacks of a logging context.
+type levelNotifier struct {
+	// mu serializes level changes, so that the callbacks see consistent old levels
+	mu        sync.Mutex
+	callbacks []func(old, new Level)
+}
+
+func (n *levelNotifier) set(b Backend, level Level) {
+	n.mu.Lock()
+	old := b.Level()
+	b.SetLevel(level)
+	// the backend may not support changing its level (see Combine)
+	level = b.Level()
+	n.mu.Unlock()
+
+	if old == level {
+		return
+	}
+
+	for _, fn := range n.callbacks {
+		fn(old, level)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelJSON(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+
+	for _, level := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, PanicLevel} {
+		b, err := json.Marshal(config{Level: level})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if want := `{"level":"` + level.String() + `"}`; string(b) != want {
+			t.Errorf("expected %s, got %s", want, b)
+		}
+
+		var c config
+		if err := json.Unmarshal(b, &c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if c.Level != level {
+			t.Errorf("expected %s, got %s", level, c.Level)
+		}
+	}
+}
+
+func TestLevelUnmarshalTextInvalid(t *testing.T) {
+	level := WarnLevel
+
+	if err := level.UnmarshalText([]byte("loud")); err == nil {
+		t.Error("expected an error")
+	}
+
+	if level != WarnLevel {
+		t.Errorf("expected the level to be left as is, got %s", level)
+	}
+}
+
+func TestMustParseLevel(t *testing.T) {
+	if level := MustParseLevel("warn"); level != WarnLevel {
+		t.Errorf("expected %s, got %s", WarnLevel, level)
+	}
+
+	defer func() {
+		if msg, _ := recover().(string); !strings.Contains(msg, `"loud"`) {
+			t.Errorf("expected a panic with the invalid level, got %q", msg)
+		}
+	}()
+
+	MustParseLevel("loud")
+}
+
+func TestLevelFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	level := InfoLevel
+	fs.Var(&level, "log-level", "logging level")
+
+	if err := fs.Parse([]string{"-log-level", "warn"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if level != WarnLevel {
+		t.Errorf("expected warn, got %s", level)
+	}
+
+	if err := fs.Parse([]string{"-log-level", "loud"}); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestWithTemporaryLevel(t *testing.T) {
+	ctx, _ := newFileContext(t, WithLevel(WarnLevel))
+
+	restore := WithTemporaryLevel(ctx, DebugLevel)
+
+	if !DebugEnabled(ctx) {
+		t.Error("expected Debug to be enabled")
+	}
+
+	restore()
+
+	if InfoEnabled(ctx) || !WarnEnabled(ctx) {
+		t.Error("expected the previous level to be restored")
+	}
+
+	WithTemporaryLevel(context.Background(), DebugLevel)()
+}
+
+func TestContextWithIndependentLevel(t *testing.T) {
+	parent, read := newFileContext(t)
+
+	child := ContextWithIndependentLevel(parent, DebugLevel)
+
+	Debug(child, "child debug")
+	Debug(parent, "parent debug")
+
+	SetLevel(child, ErrorLevel)
+
+	if !InfoEnabled(parent) {
+		t.Error("SetLevel on the child must not affect the parent")
+	}
+
+	SetLevel(parent, DebugLevel)
+
+	if InfoEnabled(ContextWithField(child, "a", 1)) {
+		t.Error("SetLevel on the parent must not affect the child")
+	}
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if records[0]["msg"] != "child debug" {
+		t.Errorf("unexpected record %v", records[0])
+	}
+}
+
+func TestContextWithIndependentLevelSplitOutput(t *testing.T) {
+	stdout, stderr := captureStd(t, func() {
+		ctx := ContextWithIndependentLevel(Context(context.Background(), WithLevelSplitOutput()),
+			DebugLevel)
+
+		Debug(ctx, "debug line")
+		Error(ctx, "error line")
+	})
+
+	if !strings.Contains(stdout, "debug line") || strings.Contains(stdout, "error line") {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+
+	if !strings.Contains(stderr, "error line") || strings.Contains(stderr, "debug line") {
+		t.Errorf("unexpected stderr %q", stderr)
+	}
+}
+
+func TestWithLevelEnabler(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		now = time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	)
+
+	// Debug is enabled outside of office hours only
+	enabler := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return l > zapcore.DebugLevel || now.Hour() < 8 || now.Hour() >= 18
+	})
+
+	ctx, read := newFileContext(t, WithLevel(DebugLevel), WithLevelEnabler(enabler))
+
+	Debug(ctx, "office hours")
+	Info(ctx, "office hours")
+
+	mu.Lock()
+	now = now.Add(12 * time.Hour)
+	mu.Unlock()
+
+	Debug(ctx, "night")
+
+	SetLevel(ctx, InfoLevel)
+	Debug(ctx, "night, at info level")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if records[0]["severity"] != "INFO" || records[1]["msg"] != "night" {
+		t.Errorf("unexpected records %v", records)
+	}
+}
+
+func TestWithLevelChangeCallback(t *testing.T) {
+	type change struct{ old, new Level }
+
+	var (
+		ctx     context.Context
+		changes []change
+	)
+
+	ctx, _ = newFileContext(t, WithLevelChangeCallback(func(old, new Level) {
+		// the callback runs once the level is changed
+		if !Enabled(ctx, new) {
+			t.Errorf("expected the level to be changed when the callback runs")
+		}
+
+		changes = append(changes, change{old, new})
+	}))
+
+	SetLevel(ctx, DebugLevel)
+	SetLevel(ctx, DebugLevel)
+	WithTemporaryLevel(ctx, ErrorLevel)()
+	SetLevel(ContextWithIndependentLevel(ctx, InfoLevel), WarnLevel)
+
+	want := []change{{InfoLevel, DebugLevel}, {DebugLevel, ErrorLevel}, {ErrorLevel, DebugLevel}}
+
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("expected %v, got %v", want, changes)
+	}
+}
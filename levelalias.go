@@ -0,0 +1,82 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelAliasCore writes the entry's level under additional keys.
+type levelAliasCore struct {
+	zapcore.Core
+	keys []string
+	// values are the levels as encoded by the logging context's level encoder
+	values map[zapcore.Level]any
+}
+
+func newLevelAliasCore(
+	core zapcore.Core, keys []string, encode zapcore.LevelEncoder,
+) *levelAliasCore {
+	if encode == nil {
+		encode = zapcore.CapitalLevelEncoder
+	}
+
+	values := make(map[zapcore.Level]any)
+
+	for level := zapcore.DebugLevel; level <= zapcore.FatalLevel; level++ {
+		enc := zapcore.NewMapObjectEncoder()
+		_ = enc.AddArray("level", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+			encode(level, ae)
+
+			return nil
+		}))
+
+		if encoded, _ := enc.Fields["level"].([]any); len(encoded) > 0 {
+			values[level] = encoded[0]
+		}
+	}
+
+	return &levelAliasCore{Core: core, keys: keys, values: values}
+}
+
+func (c *levelAliasCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *levelAliasCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.keys)+len(fields))
+
+	value, ok := c.values[entry.Level]
+	if !ok {
+		value = entry.Level.CapitalString()
+	}
+
+	for _, key := range c.keys {
+		all = append(all, zap.Any(key, value))
+	}
+
+	return c.Core.Write(entry, append(all, fields...))
+}
+
+func (c *levelAliasCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelAliasCore{
+		Core:   c.Core.With(fields),
+		keys:   c.keys,
+		values: c.values,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "testing"
+
+func TestWithDuplicateLevelKey(t *testing.T) {
+	ctx, read := newFileContext(t, WithDuplicateLevelKey("level"))
+
+	Warn(ContextWithField(ctx, "a", 1), "x")
+
+	r := read()[0]
+
+	if r["severity"] != "WARN" || r["level"] != "WARN" || r["a"] != 1.0 {
+		t.Errorf("expected both level keys with the same value, got %v", r)
+	}
+}
+
+func TestWithDuplicateLevelKeyEncoder(t *testing.T) {
+	ctx, read := newFileContext(t, WithGCPSeverity(), WithDuplicateLevelKey("level"))
+
+	Warn(ctx, "x")
+	Error(ctx, "z")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	for i, want := range []string{"WARNING", "ERROR"} {
+		if r := records[i]; r["severity"] != want || r["level"] != want {
+			t.Errorf("expected both level keys with %s, got %v", want, r)
+		}
+	}
+}
+
+func TestWithNoLevelKey(t *testing.T) {
+	ctx, read := newFileContext(t, WithNoLevelKey(), WithLevel(WarnLevel))
+
+	Info(ctx, "x")
+	Warn(ctx, "y")
+
+	r := read()
+	requireRecords(t, r, 1)
+
+	if _, ok := r[0][DefaultLevelKey]; ok || r[0]["msg"] != "y" {
+		t.Errorf("expected only the warning, without a level, got %v", r[0])
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	truncatedMarker  = "…(truncated)"
+	fieldsDroppedKey = "fields_dropped"
+)
+
+// limitsCore truncates oversized string, byte, error and fmt.Stringer field values and drops
+// fields beyond a maximum count. Limits apply to both context fields and per-record fields.
+type limitsCore struct {
+	zapcore.Core
+	maxFieldBytes  int
+	maxFields      int
+	contextFields  int
+	contextDropped int
+}
+
+func (c *limitsCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *limitsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	fields, dropped := c.limit(fields, c.contextFields)
+
+	if dropped += c.contextDropped; dropped > 0 {
+		fields = append(fields, zap.Int(fieldsDroppedKey, dropped))
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+func (c *limitsCore) With(fields []zapcore.Field) zapcore.Core {
+	fields, dropped := c.limit(fields, c.contextFields)
+
+	return &limitsCore{
+		Core:           c.Core.With(fields),
+		maxFieldBytes:  c.maxFieldBytes,
+		maxFields:      c.maxFields,
+		contextFields:  c.contextFields + len(fields),
+		contextDropped: c.contextDropped + dropped,
+	}
+}
+
+// limit returns a copy of fields that fits within the limits given that inherited fields
+// are already present, along with the number of fields dropped. Error fields are neither
+// counted nor dropped.
+func (c *limitsCore) limit(fields []zapcore.Field, inherited int) ([]zapcore.Field, int) {
+	limited := make([]zapcore.Field, 0, len(fields)+1)
+	budget := c.maxFields - inherited
+	dropped := 0
+
+	for _, f := range fields {
+		if c.maxFields > 0 && !isErrorField(f) {
+			if budget <= 0 {
+				dropped++
+
+				continue
+			}
+
+			budget--
+		}
+
+		if c.maxFieldBytes > 0 {
+			f = c.truncate(f)
+		}
+
+		limited = append(limited, f)
+	}
+
+	return limited, dropped
+}
+
+func (c *limitsCore) truncate(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.StringType:
+		if len(f.String) > c.maxFieldBytes {
+			f.String = truncateString(f.String, c.maxFieldBytes)
+		}
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		if b, ok := f.Interface.([]byte); ok && len(b) > c.maxFieldBytes {
+			f.Interface = append(b[:c.maxFieldBytes:c.maxFieldBytes], truncatedMarker...)
+		}
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil && len(err.Error()) > c.maxFieldBytes {
+			return zap.String(f.Key, truncateString(err.Error(), c.maxFieldBytes))
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok && s != nil {
+			if str := s.String(); len(str) > c.maxFieldBytes {
+				return zap.String(f.Key, truncateString(str, c.maxFieldBytes))
+			}
+		}
+	}
+
+	return f
+}
+
+func isErrorField(f zapcore.Field) bool {
+	if f.Type == zapcore.ErrorType {
+		return true
+	}
+
+	_, ok := f.Interface.(errorArray)
+
+	return ok
+}
+
+func truncateString(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n] + truncatedMarker
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithMaxFieldBytes(t *testing.T) {
+	ctx, read := newFileContext(t, WithMaxFieldBytes(5))
+
+	ctx = ContextWithField(ctx, "ctx", strings.Repeat("é", 10))
+
+	Info(ctx, "x",
+		WithField("short", "abc"),
+		WithField("long", "0123456789"),
+		WithError(errors.New(strings.Repeat("e", 100))),
+	)
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	want := map[string]any{
+		"ctx":   "éé" + truncatedMarker,
+		"short": "abc",
+		"long":  "01234" + truncatedMarker,
+		"error": "eeeee" + truncatedMarker,
+	}
+
+	for k, v := range want {
+		if records[0][k] != v {
+			t.Errorf("expected %s=%q, got %q", k, v, records[0][k])
+		}
+	}
+}
+
+func TestWithMaxFields(t *testing.T) {
+	ctx, read := newFileContext(t, WithMaxFields(3))
+
+	ctx = ContextWithField(ctx, "a", 1)
+	ctx = ContextWithField(ctx, "b", 2)
+
+	Info(ctx, "x", WithFields(Fields{"d": 4, "c": 3}), WithError(errors.New("boom")))
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	r := records[0]
+
+	for _, k := range []string{"a", "b", "c", "error"} {
+		if _, ok := r[k]; !ok {
+			t.Errorf("expected field %q to be kept: %v", k, r)
+		}
+	}
+
+	if _, ok := r["d"]; ok {
+		t.Errorf("expected field d to be dropped: %v", r)
+	}
+
+	if r[fieldsDroppedKey] != 1.0 {
+		t.Errorf("expected 1 dropped field, got %v", r[fieldsDroppedKey])
+	}
+}
+
+func TestLimitsCoreDoesNotMutateFields(t *testing.T) {
+	obs, logs := observer.New(zapcore.DebugLevel)
+
+	core := &limitsCore{Core: obs, maxFields: 1, maxFieldBytes: 1}
+
+	fields := []zapcore.Field{zap.String("a", "aa"), zap.Int("b", 2)}
+
+	if err := core.Write(zapcore.Entry{}, fields); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fields[0].String != "aa" || fields[1].Key != "b" {
+		t.Errorf("caller's fields were modified: %v", fields)
+	}
+
+	if got := logs.All()[0].ContextMap(); got[fieldsDroppedKey] != int64(1) {
+		t.Errorf("unexpected fields: %v", got)
+	}
+}
+
+func TestWithMaxFieldsCombined(t *testing.T) {
+	limited, readLimited := newFileContext(t, WithMaxFields(1))
+	plain, readPlain := newFileContext(t)
+
+	Info(Combine(limited, plain), "x", WithField("a", 1), WithField("b", 2))
+
+	records := readPlain()
+	requireRecords(t, records, 1)
+
+	if records[0]["a"] != 1.0 || records[0]["b"] != 2.0 {
+		t.Errorf("unlimited context lost fields: %v", records[0])
+	}
+
+	if _, ok := records[0][fieldsDroppedKey]; ok {
+		t.Errorf("unlimited context got fields_dropped: %v", records[0])
+	}
+
+	records = readLimited()
+	requireRecords(t, records, 1)
+
+	if _, ok := records[0]["b"]; ok || records[0][fieldsDroppedKey] != 1.0 {
+		t.Errorf("unexpected limited record: %v", records[0])
+	}
+}
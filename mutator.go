@@ -0,0 +1,74 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"slices"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithEntryMutator registers fn to transform the records of the logging context just before
+// they're written, eg. to strip secrets from messages. fn may modify the entry (its message,
+// level, etc.) and returns the fields to write, which may be those it's given (including the
+// context fields), modified in place, or others. Unlike hooks (see WithHooks), which only
+// observe the records, fn changes what is written; hooks see the transformed records.
+//
+// Mutators run in the order they're registered, in the goroutine that logs the record. A
+// mutator changing the level of an entry only changes the level written: eg. an entry raised to
+// FatalLevel doesn't exit.
+func WithEntryMutator(
+	fn func(entry *zapcore.Entry, fields []zapcore.Field) []zapcore.Field,
+) ContextOption {
+	return func(o *contextOptions) {
+		o.mutators = append(o.mutators, fn)
+	}
+}
+
+// mutatorCore transforms the entries it writes, and their fields, with mutators.
+//
+// Like rewriteCore, it keeps the context fields it is given through With rather than passing
+// them down to the wrapped core, so that the mutators see them along with the record's fields.
+type mutatorCore struct {
+	zapcore.Core
+	mutators []func(*zapcore.Entry, []zapcore.Field) []zapcore.Field
+	context  []zapcore.Field
+}
+
+func (c *mutatorCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+// Write passes the mutators a copy of the fields, which they may modify in place.
+func (c *mutatorCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	all = append(append(all, c.context...), fields...)
+
+	for _, mutate := range c.mutators {
+		all = mutate(&entry, all)
+	}
+
+	return c.Core.Write(entry, all)
+}
+
+func (c *mutatorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &mutatorCore{
+		Core:     c.Core,
+		mutators: c.mutators,
+		context:  append(slices.Clip(c.context), fields...),
+	}
+}
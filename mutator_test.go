@@ -0,0 +1,76 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithEntryMutator(t *testing.T) {
+	var hooked []string
+
+	ctx, read := newFileContext(t,
+		WithEntryMutator(func(entry *zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+			entry.Message = strings.ReplaceAll(entry.Message, "hunter2", "***")
+
+			return slices.DeleteFunc(fields, func(f zapcore.Field) bool {
+				return f.Key == "password"
+			})
+		}),
+		WithEntryMutator(func(entry *zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+			if entry.Level == zapcore.InfoLevel && strings.Contains(entry.Message, "login") {
+				entry.Level = zapcore.WarnLevel
+			}
+
+			return fields
+		}),
+		WithHooks(func(entry zapcore.Entry, fields []zapcore.Field) {
+			hooked = append(hooked, entry.Message)
+
+			for _, f := range fields {
+				if f.Key == "password" {
+					t.Error("expected hooks to see the mutated fields")
+				}
+			}
+		}),
+	)
+
+	ctx = ContextWithField(ctx, "password", "hunter2")
+
+	Info(ctx, "login with hunter2", WithField("user", "bob"))
+	Error(ctx, "other")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if r := records[0]; r["msg"] != "login with ***" || r["severity"] != "WARN" ||
+		r["user"] != "bob" {
+		t.Errorf("unexpected record: %v", r)
+	}
+
+	for _, r := range records {
+		if _, ok := r["password"]; ok {
+			t.Errorf("expected the field to be dropped: %v", r)
+		}
+	}
+
+	if len(hooked) != 2 || hooked[0] != "login with ***" {
+		t.Errorf("expected hooks to see the mutated entries, got %v", hooked)
+	}
+}
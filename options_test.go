@@ -0,0 +1,383 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithSecretMetadata(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	secret := []byte("super-secret-api-key-value")
+
+	Info(ctx, "rotated key", WithSecretMetadata("kid-1", 3, secret))
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	r := records[0]
+
+	if r["key_id"] != "kid-1" || r["key_version"] != 3.0 {
+		t.Errorf("unexpected metadata: %v", r)
+	}
+
+	fp, _ := r["key_fingerprint"].(string)
+	if !regexp.MustCompile(`^sha256:[0-9a-f]{16}$`).MatchString(fp) {
+		t.Errorf("unexpected fingerprint %q", fp)
+	}
+
+	raw, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(raw), string(secret)) {
+		t.Errorf("raw secret value leaked into the record: %s", raw)
+	}
+}
+
+func TestWithSecretMetadataIsStable(t *testing.T) {
+	a := &options{}
+	WithSecretMetadata("k", 1, []byte("v"))(a)
+
+	b := &options{}
+	WithSecretMetadata("k", 1, []byte("v"))(b)
+
+	if a.fields["key_fingerprint"] != b.fields["key_fingerprint"] {
+		t.Error("expected the same value to produce the same fingerprint")
+	}
+}
+
+func TestWithTTL(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Info(ctx, "x", WithTTL(90*time.Minute+500*time.Millisecond))
+
+	if got := read()[0][TTLKey]; got != 5400.0 {
+		t.Errorf("expected ttl of 5400 seconds, got %v", got)
+	}
+}
+
+func TestWithBucketedField(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	buckets := []float64{100, 1, 10}
+
+	for _, v := range []float64{0.5, 1, 7, 100, 250} {
+		Info(ctx, "x", WithBucketedField("latency_ms", v, buckets))
+	}
+
+	records := read()
+	requireRecords(t, records, 5)
+
+	for i, want := range []string{"1", "1", "10", "100", "+Inf"} {
+		if got := records[i]["latency_ms_bucket"]; got != want {
+			t.Errorf("record %d: expected bucket %s, got %v", i, want, got)
+		}
+	}
+
+	if records[2]["latency_ms"] != 7.0 {
+		t.Errorf("expected the raw value, got %v", records[2])
+	}
+}
+
+func TestWithLazy(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	calls := 0
+	fn := func() any {
+		calls++
+
+		return map[string]int{"size": 3}
+	}
+
+	Debug(ctx, "disabled", WithLazy("body", fn))
+
+	if calls != 0 {
+		t.Errorf("expected the lazy field not to be evaluated, got %d calls", calls)
+	}
+
+	Info(ctx, "enabled", WithLazy("body", fn))
+
+	if calls != 1 {
+		t.Errorf("expected the lazy field to be evaluated once, got %d calls", calls)
+	}
+
+	body, _ := read()[0]["body"].(map[string]any)
+	if body["size"] != 3.0 {
+		t.Errorf("unexpected lazy value %v", body)
+	}
+}
+
+func TestWithNamespace(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Info(ctx, "x",
+		WithField("top", 1),
+		WithNamespace("http"),
+		WithField("method", "GET"),
+		WithError(errors.New("boom")),
+		WithNamespace("resp"),
+		WithFields(Fields{"status": 200}),
+	)
+
+	r := read()[0]
+
+	if r["top"] != 1.0 || r[DefaultErrorKey] != "boom" {
+		t.Errorf("expected top-level fields, got %v", r)
+	}
+
+	http, _ := r["http"].(map[string]any)
+	if http["method"] != "GET" {
+		t.Fatalf("expected nested http fields, got %v", r)
+	}
+
+	resp, _ := http["resp"].(map[string]any)
+	if resp["status"] != 200.0 {
+		t.Errorf("expected nested resp fields, got %v", http)
+	}
+}
+
+func TestWithCount(t *testing.T) {
+	var counted int64
+
+	ctx, read := newFileContext(t, WithHooks(func(_ zapcore.Entry, fields []zapcore.Field) {
+		for _, f := range fields {
+			if f.Key == "requests" && f.Type == zapcore.Int64Type {
+				counted += f.Integer
+			}
+		}
+	}))
+
+	Info(ctx, "x", WithCount("requests", 2))
+	Info(ctx, "x", WithCount("requests", 3))
+
+	if r := read(); r[0]["requests"] != 2.0 || r[1]["requests"] != 3.0 {
+		t.Errorf("expected the counts, got %v", r)
+	}
+
+	if counted != 5 {
+		t.Errorf("expected hooks to see typed counts adding up to 5, got %d", counted)
+	}
+}
+
+func TestWithDurationUnit(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Info(ctx, "x",
+		WithDurationMillis("latency_ms", 1500*time.Microsecond),
+		WithDurationUnit("latency_s", 2500*time.Millisecond, time.Second),
+	)
+
+	if r := read()[0]; r["latency_ms"] != 1.5 || r["latency_s"] != 2.5 {
+		t.Errorf("expected durations in the given units, got %v", r)
+	}
+}
+
+func TestWithDurationEncoder(t *testing.T) {
+	ctx, read := newFileContext(t, WithDurationEncoder(zapcore.MillisDurationEncoder))
+
+	ctx = ContextWithField(ctx, "timeout", time.Second)
+
+	Info(ctx, "x", WithField("latency", 2*time.Millisecond))
+
+	if r := read()[0]; r["timeout"] != 1000.0 || r["latency"] != 2.0 {
+		t.Errorf("expected durations in milliseconds, got %v", r)
+	}
+}
+
+func TestWithRawJSON(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding())
+
+	Info(ctx, "x", WithRawJSON("valid", []byte(`{"a": [1, 2]}`)))
+	Info(ctx, "x", WithRawJSON("invalid", []byte(`{"a": `)))
+
+	want := `{"severity":"INFO","msg":"x","valid":{"a":[1,2]}}` + "\n" +
+		`{"severity":"INFO","msg":"x","field_error":"invalid","invalid":"{\"a\": "}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithSkip(t *testing.T) {
+	var (
+		buf   strings.Builder
+		calls int
+	)
+
+	ctx, read := newFileContext(t, WithExplain(&buf))
+
+	lazy := WithLazy("a", func() any {
+		calls++
+
+		return 1
+	})
+
+	Info(ctx, "skipped", lazy, WithSkip(true))
+	Info(ctx, "kept", lazy, WithSkip(false))
+	Log(ctx, WarnLevel, "skipped", WithSkip(true), WithSkip(false))
+	Panic(ctx, "skipped", WithSkip(true))
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if records[0]["msg"] != "kept" || calls != 1 {
+		t.Errorf("expected only the kept record to be assembled, got %v (%d calls)", records, calls)
+	}
+
+	want := "suppressed INFO \"skipped\": skipped\n" +
+		"suppressed WARN \"skipped\": skipped\n" +
+		"suppressed PANIC \"skipped\": skipped\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithForce(t *testing.T) {
+	hook, entries := recorder()
+
+	ctx, read := newFileContext(t, WithLevel(ErrorLevel), WithHooks(hook))
+
+	Debug(ctx, "forced", WithForce(), WithField("a", 1))
+	Debug(ctx, "dropped")
+	Log(ctx, InfoLevel, "forced", WithForce())
+	Info(ctx, "dropped", WithForce(), WithSkip(true))
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	for i, want := range []string{"DEBUG", "INFO"} {
+		if r := records[i]; r["msg"] != "forced" || r["severity"] != want {
+			t.Errorf("expected a forced %s record, got %v", want, r)
+		}
+	}
+
+	if len(*entries) != 2 {
+		t.Errorf("expected the hooks to see the forced records, got %v", *entries)
+	}
+
+	if DebugEnabled(ctx) {
+		t.Error("expected forcing a record not to change the level")
+	}
+}
+
+func TestWithForceBackend(t *testing.T) {
+	backend := NewMemoryBackend(ErrorLevel)
+
+	ctx := Context(nil, WithBackend(backend), WithLevel(ErrorLevel))
+
+	Debug(ctx, "forced", WithForce())
+	Debug(ctx, "dropped")
+
+	if records := backend.Records(); len(records) != 1 || records[0].Msg != "forced" {
+		t.Errorf("expected the forced record, got %v", records)
+	}
+}
+
+func TestWithMap(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding())
+
+	Info(ctx, "x", WithMap("meta", map[string]string{"b": "2", "a": "1", "c": "3"}))
+
+	want := `{"severity":"INFO","msg":"x","meta":{"a":"1","b":"2","c":"3"}}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func BenchmarkWithMap(b *testing.B) {
+	m := map[string]string{"region": "eu-west-1", "zone": "b", "tier": "gold", "team": "core"}
+
+	for _, bc := range []struct {
+		name string
+		opt  func() Option
+	}{
+		{"WithMap", func() Option { return WithMap("meta", m) }},
+		{"WithField", func() Option { return WithField("meta", m) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := Context(context.Background(),
+				WithJSONEncoding(),
+				WithRotatingFile(filepath.Join(b.TempDir(), "bench.log"), 0, 0, 0),
+			)
+			defer Close(ctx)
+
+			b.ReportAllocs()
+
+			for range b.N {
+				Info(ctx, "x", bc.opt())
+			}
+		})
+	}
+}
+
+func TestWithStringsAndInts(t *testing.T) {
+	type tags []string
+
+	ctx, read := newRawContext(t, WithJSONEncoding())
+
+	Info(ctx, "x",
+		WithStrings("tags", tags{"a", "b"}),
+		WithInts("ports", []int{80, 443}),
+		WithStrings("none", nil),
+	)
+
+	want := `{"severity":"INFO","msg":"x","none":[],"ports":[80,443],"tags":["a","b"]}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func BenchmarkWithStrings(b *testing.B) {
+	type tags []string
+
+	vals := tags{"eu-west-1", "b", "gold", "core"}
+
+	for _, bc := range []struct {
+		name string
+		opt  func() Option
+	}{
+		{"WithStrings", func() Option { return WithStrings("tags", vals) }},
+		{"WithField", func() Option { return WithField("tags", vals) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := Context(context.Background(),
+				WithJSONEncoding(),
+				WithRotatingFile(filepath.Join(b.TempDir(), "bench.log"), 0, 0, 0),
+			)
+			defer Close(ctx)
+
+			b.ReportAllocs()
+
+			for range b.N {
+				Info(ctx, "x", bc.opt())
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap/zapcore"
+
+// WithFieldPrefix prepends prefix and an underscore to the key of every field of the logging
+// context's records (eg. "svc_user_id" for "user_id" with prefix "svc"), to avoid collisions
+// when several libraries log into one stream. It applies to context fields, record fields and
+// errors, but not to the level, message and time keys, nor to fields nested in a namespace.
+//
+// Hooks (see WithHooks) see the fields without the prefix.
+func WithFieldPrefix(prefix string) ContextOption {
+	return func(o *contextOptions) {
+		o.fieldPrefix = prefix
+	}
+}
+
+// prefixCore prefixes the keys of top-level fields.
+type prefixCore struct {
+	zapcore.Core
+	prefix string
+	// nested is set once a context field opened a namespace, which holds all the fields
+	// that follow
+	nested bool
+}
+
+func (c *prefixCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *prefixCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.nested {
+		return c.Core.Write(entry, fields)
+	}
+
+	prefixed, _ := c.prefixed(fields)
+
+	return c.Core.Write(entry, prefixed)
+}
+
+func (c *prefixCore) With(fields []zapcore.Field) zapcore.Core {
+	if c.nested {
+		return &prefixCore{Core: c.Core.With(fields), prefix: c.prefix, nested: true}
+	}
+
+	prefixed, nested := c.prefixed(fields)
+
+	return &prefixCore{Core: c.Core.With(prefixed), prefix: c.prefix, nested: nested}
+}
+
+// prefixed returns a copy of fields with the keys of top-level fields prefixed, and whether
+// fields opened a namespace.
+func (c *prefixCore) prefixed(fields []zapcore.Field) ([]zapcore.Field, bool) {
+	prefixed := make([]zapcore.Field, len(fields))
+	copy(prefixed, fields)
+
+	for i := range prefixed {
+		prefixed[i].Key = c.prefix + "_" + prefixed[i].Key
+
+		if prefixed[i].Type == zapcore.NamespaceType {
+			return prefixed, true
+		}
+	}
+
+	return prefixed, false
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestWithFieldPrefix(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithFieldPrefix("svc"))
+	ctx = ContextWithField(ctx, "user_id", 1)
+
+	Info(ctx, "x", WithField("a", 2), WithError(errors.New("failed")),
+		WithNamespace("n"), WithField("b", 3),
+	)
+
+	want := `{"severity":"INFO","msg":"x","svc_user_id":1,"svc_a":2,"svc_error":"failed",` +
+		`"svc_n":{"b":3}}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithFieldPrefixNestedContext(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithFieldPrefix("svc"))
+
+	b, _ := backendOf(ctx)
+	ctx = withZapFields(ctx, b, zap.Int("a", 1), zap.Namespace("n"))
+
+	Info(ctx, "x", WithField("b", 2))
+
+	want := `{"severity":"INFO","msg":"x","svc_a":1,"svc_n":{"b":2}}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// StackKey is the key that has the stack trace of a recovered panic as value (see Recover).
+const StackKey = "stack"
+
+// Recover recovers from a panic and logs it at ErrorLevel, with the panic value as error and
+// the stack trace under "stack". It must be deferred directly, eg. at the top of a goroutine:
+//
+//	go func() {
+//		defer clog.Recover(ctx)
+//		...
+//	}()
+//
+// Recover does nothing if there is no panic. If ctx is not a logging context then the panic
+// is not recovered either, so it isn't silently swallowed.
+func Recover(ctx context.Context) {
+	if _, ok := backendOf(ctx); !ok {
+		return
+	}
+
+	if v := recover(); v != nil {
+		// the record is annotated with the location of the panic, Recover is called by it
+		Error(skipCallers(ctx, 2), "recovered from panic", panicOptions(v)...)
+	}
+}
+
+// RecoverAndExit is like Recover but logs the panic at FatalLevel, which then calls
+// os.Exit(1).
+func RecoverAndExit(ctx context.Context) {
+	if _, ok := backendOf(ctx); !ok {
+		return
+	}
+
+	if v := recover(); v != nil {
+		Fatal(skipCallers(ctx, 2), "recovered from panic", panicOptions(v)...)
+	}
+}
+
+func panicOptions(v any) []Option {
+	err, ok := v.(error)
+	if !ok {
+		err = fmt.Errorf("%v", v)
+	}
+
+	return []Option{WithError(err), WithField(StackKey, string(debug.Stack()))}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	func() {
+		defer Recover(ctx)
+
+		panic("boom")
+	}()
+
+	func() {
+		defer Recover(ctx)
+	}()
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if r := records[0]; r["severity"] != "ERROR" || r[DefaultErrorKey] != "boom" {
+		t.Errorf("expected the panic logged as an error, got %v", r)
+	}
+
+	if stack, _ := records[0][StackKey].(string); !strings.Contains(stack, "TestRecover") {
+		t.Errorf("expected the stack trace, got %q", stack)
+	}
+}
+
+func TestRecoverWithoutLoggingContext(t *testing.T) {
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Errorf("expected the panic to propagate, got %v", v)
+		}
+	}()
+
+	func() {
+		defer Recover(context.Background())
+
+		panic("boom")
+	}()
+}
+
+func TestRecoverAndExit(t *testing.T) {
+	if path := os.Getenv("CLOG_RECOVER_AND_EXIT"); path != "" {
+		ctx := Context(context.Background(),
+			WithJSONEncoding(), WithNoTimeKey(), WithOutputPath(path),
+		)
+
+		defer RecoverAndExit(ctx)
+
+		panic("boom")
+	}
+
+	path := filepath.Join(t.TempDir(), "test.log")
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRecoverAndExit$")
+	cmd.Env = append(os.Environ(), "CLOG_RECOVER_AND_EXIT="+path)
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit code 1, got %v", err)
+	}
+
+	records := readRecords(t, path)
+	requireRecords(t, records, 1)
+
+	if r := records[0]; r["severity"] != "FATAL" || r[DefaultErrorKey] != "boom" {
+		t.Errorf("expected the panic logged as fatal, got %v", r)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RegionKey is the key that has the deployment region as value.
+const RegionKey = "region"
+
+const regionMetadataTimeout = time.Second
+
+// regionMetadataURL is the base URL of the (AWS-style) instance metadata service.
+var regionMetadataURL = "http://169.254.169.254"
+
+var regionCache struct {
+	once   sync.Once
+	region string
+}
+
+// WithRegion attaches a "region" field with the given deployment region to every log record.
+// It is a no-op if region is empty, so it can be fed directly from an environment variable.
+func WithRegion(region string) ContextOption {
+	return func(o *contextOptions) {
+		if region != "" {
+			o.fields = append(o.fields, zap.String(RegionKey, region))
+		}
+	}
+}
+
+// WithRegionFromMetadata attaches a "region" field with the region reported by the cloud
+// instance metadata service (IMDS) to every log record.
+//
+// The metadata service is queried once per process with a short timeout and the result is
+// cached. If it can't be reached the field is omitted.
+func WithRegionFromMetadata() ContextOption {
+	return func(o *contextOptions) {
+		regionCache.once.Do(func() {
+			regionCache.region = queryRegionMetadata()
+		})
+
+		WithRegion(regionCache.region)(o)
+	}
+}
+
+func queryRegionMetadata() string {
+	ctx, cancel := context.WithTimeout(context.Background(), regionMetadataTimeout)
+	defer cancel()
+
+	get, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, regionMetadataURL+"/latest/meta-data/placement/region", http.NoBody,
+	)
+	if err != nil {
+		return ""
+	}
+
+	// IMDSv2 requires a session token; IMDSv1 endpoints work without one
+	if token := queryMetadataToken(ctx); token != "" {
+		get.Header.Set("X-aws-ec2-metadata-token", token)
+	}
+
+	body, ok := doMetadataRequest(get)
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(body)
+}
+
+func queryMetadataToken(ctx context.Context) string {
+	put, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, regionMetadataURL+"/latest/api/token", http.NoBody,
+	)
+	if err != nil {
+		return ""
+	}
+
+	put.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+
+	token, _ := doMetadataRequest(put)
+
+	return token
+}
+
+func doMetadataRequest(req *http.Request) (string, bool) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", false
+	}
+
+	return string(body), true
+}
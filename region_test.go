@@ -0,0 +1,113 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func resetRegionCache(t *testing.T, url string) {
+	t.Helper()
+
+	orig := regionMetadataURL
+	regionMetadataURL = url
+	regionCache.once = sync.Once{}
+	regionCache.region = ""
+
+	t.Cleanup(func() {
+		regionMetadataURL = orig
+		regionCache.once = sync.Once{}
+		regionCache.region = ""
+	})
+}
+
+func TestWithRegion(t *testing.T) {
+	ctx, read := newFileContext(t, WithRegion("eu-west-1"))
+
+	Info(ctx, "x")
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if records[0][RegionKey] != "eu-west-1" {
+		t.Errorf("unexpected region: %v", records[0])
+	}
+}
+
+func TestWithRegionEmpty(t *testing.T) {
+	ctx, read := newFileContext(t, WithRegion(""))
+
+	Info(ctx, "x")
+
+	if _, ok := read()[0][RegionKey]; ok {
+		t.Error("expected no region field")
+	}
+}
+
+func TestWithRegionFromMetadata(t *testing.T) {
+	calls := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/api/token":
+			_, _ = w.Write([]byte("token"))
+		case "/latest/meta-data/placement/region":
+			calls++
+
+			if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte("us-east-2\n"))
+		}
+	}))
+	defer srv.Close()
+
+	resetRegionCache(t, srv.URL)
+
+	for range 2 {
+		ctx, read := newFileContext(t, WithRegionFromMetadata())
+
+		Info(ctx, "x")
+
+		if got := read()[0][RegionKey]; got != "us-east-2" {
+			t.Errorf("unexpected region %v", got)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected the metadata to be queried once, got %d", calls)
+	}
+}
+
+func TestWithRegionFromMetadataUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	resetRegionCache(t, srv.URL)
+
+	ctx, read := newFileContext(t, WithRegionFromMetadata())
+
+	Info(ctx, "x")
+
+	if _, ok := read()[0][RegionKey]; ok {
+		t.Error("expected no region field")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	// AttemptKey is the key that has the retry attempt number as value (see LogRetry).
+	AttemptKey = "attempt"
+	// MaxAttemptsKey is the key that has the maximum number of attempts as value.
+	MaxAttemptsKey = "max_attempts"
+	// BackoffKey is the key that has the backoff before the next attempt as value.
+	BackoffKey = "backoff"
+)
+
+// LogRetry logs an attempt of a retry loop with standard fields: the attempt number, the
+// maximum number of attempts, the backoff before the next attempt and the attempt's error.
+//
+// Failed attempts (err != nil) are logged at WarnLevel and successful ones at InfoLevel,
+// escalated according to the attempt number (see WithEscalation).
+func LogRetry(
+	ctx context.Context, attempt, maxAttempts int, backoff time.Duration, err error,
+) {
+	ctx = skipCallers(ctx, 1)
+	opts := []Option{WithAttempt(attempt, maxAttempts)}
+
+	if err == nil {
+		Info(ctx, "attempt succeeded", opts...)
+
+		return
+	}
+
+	opts = append(opts, WithField(BackoffKey, backoff), WithError(err))
+
+	Warn(ctx, "attempt failed", opts...)
+}
+
+// WithAttempt marks the log record as logged by attempt n (starting at 1) out of max, eg. in a
+// retry loop. The numbers are emitted under AttemptKey and MaxAttemptsKey, and n escalates the
+// level of the record (see WithEscalation).
+func WithAttempt(n, max int) Option {
+	return func(o *options) {
+		o.attempt = n
+		o.set(AttemptKey, n)
+		o.set(MaxAttemptsKey, max)
+	}
+}
+
+// WithEscalation raises the level of the records logged with WithAttempt as the attempts
+// increase: a record is logged at the level of the greatest threshold up to its attempt
+// number, if above the level it is logged at. Eg. with
+//
+//	clog.WithEscalation(map[int]clog.Level{3: clog.WarnLevel, 5: clog.ErrorLevel})
+//
+// a record logged with clog.Info is logged at InfoLevel by the first two attempts, at
+// WarnLevel by the third and fourth, and at ErrorLevel from the fifth on. Records aren't
+// escalated above ErrorLevel: a threshold at a higher level makes NewContext fail.
+func WithEscalation(thresholds map[int]Level) ContextOption {
+	return func(o *contextOptions) {
+		for _, n := range slices.Sorted(maps.Keys(thresholds)) {
+			if level := thresholds[n]; level > ErrorLevel {
+				o.errs = append(o.errs, fmt.Errorf("escalation to %s at attempt %d", level, n))
+
+				return
+			}
+		}
+
+		o.record.escalation = maps.Clone(thresholds)
+	}
+}
+
+// escalate returns the level to log a record at level with opts, raised according to its
+// attempt number (see WithEscalation). The options are only applied if the logging context
+// escalates records.
+func escalate(ctx context.Context, level Level, opts []Option) Level {
+	rc, ok := ctx.Value(recordKey).(*recordConfig)
+	if !ok || len(rc.escalation) == 0 || level >= ErrorLevel {
+		return level
+	}
+
+	o := &options{}
+
+	for i := range opts {
+		opts[i](o)
+	}
+
+	threshold := 0
+
+	for n := range rc.escalation {
+		if n <= o.attempt && n > threshold {
+			threshold = n
+		}
+	}
+
+	if escalated, ok := rc.escalation[threshold]; ok && threshold > 0 && escalated > level {
+		return escalated
+	}
+
+	return level
+}
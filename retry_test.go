@@ -0,0 +1,110 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLogRetry(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	LogRetry(ctx, 1, 3, time.Second, errors.New("timeout"))
+	LogRetry(ctx, 2, 3, 2*time.Second, errors.New("timeout"))
+	LogRetry(ctx, 3, 3, 0, nil)
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	for i, want := range []struct {
+		level   string
+		attempt float64
+		backoff any
+	}{
+		{"WARN", 1, float64(time.Second)},
+		{"WARN", 2, float64(2 * time.Second)},
+		{"INFO", 3, nil},
+	} {
+		r := records[i]
+
+		if r["severity"] != want.level || r[AttemptKey] != want.attempt || r[MaxAttemptsKey] != 3.0 {
+			t.Errorf("unexpected record %d: %v", i, r)
+		}
+
+		if r[BackoffKey] != want.backoff {
+			t.Errorf("expected backoff %v on record %d, got %v", want.backoff, i, r[BackoffKey])
+		}
+	}
+
+	if records[0][DefaultErrorKey] != "timeout" {
+		t.Errorf("expected the attempt's error, got %v", records[0])
+	}
+}
+
+func TestWithEscalation(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(DebugLevel), WithEscalation(map[int]Level{
+		3: WarnLevel,
+		5: ErrorLevel,
+	}))
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		Debug(ctx, "retrying", WithAttempt(attempt, 6))
+	}
+
+	Error(ctx, "not lowered", WithAttempt(1, 6))
+	Log(ctx, InfoLevel, "dynamic", WithAttempt(4, 6))
+	Info(ctx, "no attempt")
+
+	records := read()
+	requireRecords(t, records, 9)
+
+	for i, want := range []string{
+		"DEBUG", "DEBUG", "WARN", "WARN", "ERROR", "ERROR", "ERROR", "WARN", "INFO",
+	} {
+		if got := records[i]["severity"]; got != want {
+			t.Errorf("record %d: expected %s, got %v", i, want, got)
+		}
+	}
+
+	if r := records[0]; r[AttemptKey] != 1.0 || r[MaxAttemptsKey] != 6.0 {
+		t.Errorf("unexpected attempt fields: %v", r)
+	}
+}
+
+func TestWithEscalationBelowLevel(t *testing.T) {
+	ctx, read := newFileContext(t, WithEscalation(map[int]Level{2: InfoLevel}))
+
+	LogRetry(ctx, 1, 2, time.Second, errors.New("timeout"))
+	Debug(ctx, "hidden", WithAttempt(1, 2))
+	Debug(ctx, "escalated", WithAttempt(2, 2))
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if r := records[1]; r["msg"] != "escalated" || r["severity"] != "INFO" {
+		t.Errorf("expected the escalated record, got %v", r)
+	}
+}
+
+func TestWithEscalationInvalid(t *testing.T) {
+	if _, err := NewContext(
+		context.Background(), WithEscalation(map[int]Level{3: FatalLevel}),
+	); err == nil {
+		t.Error("expected an error escalating to FatalLevel")
+	}
+}
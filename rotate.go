@@ -0,0 +1,243 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSizeMB = 100
+	megabyte         = 1024 * 1024
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+type rotationConfig struct {
+	path       string
+	maxSizeMB  int
+	maxBackups int
+	maxAgeDays int
+}
+
+// rotatingFile is a zapcore.WriteSyncer that rotates the underlying file by size and prunes
+// old backups by count and age.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+	file       *os.File
+	size       int64
+}
+
+func newRotatingFile(cfg *rotationConfig) (*rotatingFile, error) {
+	maxSizeMB := cfg.maxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+
+	r := &rotatingFile{
+		path:       cfg.path,
+		maxSize:    int64(maxSizeMB) * megabyte,
+		maxBackups: cfg.maxBackups,
+		maxAge:     time.Duration(cfg.maxAgeDays) * 24 * time.Hour,
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rotateErr error
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		// a failed rotation leaves the current file open, so keep writing to it
+		rotateErr = r.rotate()
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	if err != nil {
+		return n, err
+	}
+
+	return n, rotateErr
+}
+
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Sync()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.file.Close()
+}
+
+func (r *rotatingFile) open() error {
+	f, size, err := openLogFile(r.path)
+	if err != nil {
+		return err
+	}
+
+	r.file = f
+	r.size = size
+
+	return nil
+}
+
+// rotate renames the current file to a backup and opens a new file in its place. The
+// current file is only closed once the new one is open, so that a failure at any step
+// leaves a writable file behind.
+func (r *rotatingFile) rotate() error {
+	backup := r.backupName(time.Now())
+
+	if err := os.Rename(r.path, backup); err != nil {
+		return fmt.Errorf("failed to rename log file: %w", err)
+	}
+
+	f, size, err := openLogFile(r.path)
+	if err != nil {
+		return err
+	}
+
+	_ = r.file.Close()
+
+	r.file = f
+	r.size = size
+
+	r.prune()
+
+	return nil
+}
+
+// backupName returns a backup path for the given time that doesn't exist yet.
+func (r *rotatingFile) backupName(t time.Time) string {
+	prefix, ext := r.backupPrefixAndExt()
+	ts := t.Format(backupTimeFormat)
+
+	name := prefix + ts + ext
+
+	for i := 1; fileExists(name); i++ {
+		name = prefix + ts + "-" + strconv.Itoa(i) + ext
+	}
+
+	return name
+}
+
+// prune removes backups exceeding maxBackups or older than maxAge. Failures are ignored
+// as they must not interrupt logging.
+func (r *rotatingFile) prune() {
+	if r.maxBackups <= 0 && r.maxAge <= 0 {
+		return
+	}
+
+	prefix, ext := r.backupPrefixAndExt()
+
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path string
+		t    time.Time
+	}
+
+	backups := make([]backup, 0, len(matches))
+
+	for _, m := range matches {
+		ts := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)
+		if len(ts) < len(backupTimeFormat) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(backupTimeFormat, ts[:len(backupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backup{path: m, t: t})
+	}
+
+	// newest first; backups sharing a timestamp are ordered by their uniqueness suffix
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].t.Equal(backups[j].t) {
+			return len(backups[i].path) > len(backups[j].path) ||
+				(len(backups[i].path) == len(backups[j].path) && backups[i].path > backups[j].path)
+		}
+
+		return backups[i].t.After(backups[j].t)
+	})
+
+	cutoff := time.Now().Add(-r.maxAge)
+
+	for i, b := range backups {
+		if (r.maxBackups > 0 && i >= r.maxBackups) || (r.maxAge > 0 && b.t.Before(cutoff)) {
+			_ = os.Remove(b.path)
+		}
+	}
+}
+
+func (r *rotatingFile) backupPrefixAndExt() (prefix, ext string) {
+	ext = filepath.Ext(r.path)
+
+	return strings.TrimSuffix(r.path, ext) + "-", ext
+}
+
+func openLogFile(path string) (*os.File, int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, 0, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+
+		return nil, 0, fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	return f, info.Size(), nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+
+	return err == nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	ctx, err := NewContext(context.Background(), WithRotatingFile(path, 1, 2, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer Close(ctx)
+
+	pad := strings.Repeat("x", 1024)
+
+	for range 3 * 1024 {
+		Info(ctx, "hello", WithField("pad", pad))
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(backups) == 0 || len(backups) > 2 {
+		t.Fatalf("expected 1 or 2 backups, got %v", backups)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Size() > megabyte {
+		t.Errorf("expected current file to be at most 1MB, got %d bytes", info.Size())
+	}
+}
+
+func TestRotatingFileUniqueBackups(t *testing.T) {
+	dir := t.TempDir()
+
+	r, err := newRotatingFile(&rotationConfig{path: filepath.Join(dir, "app.log")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer r.Close()
+
+	for range 3 {
+		if _, err := r.Write([]byte("line\n")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := r.rotate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "app-*.log"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(backups) != 3 {
+		t.Fatalf("expected 3 distinct backups, got %v", backups)
+	}
+}
+
+func TestRotatingFileRotationFailureKeepsWriting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	r, err := newRotatingFile(&rotationConfig{path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer r.Close()
+
+	// renaming fails because the file is gone
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := r.rotate(); err == nil {
+		t.Fatal("expected rotation to fail")
+	}
+
+	if _, err := r.Write([]byte("still writable\n")); err != nil {
+		t.Errorf("expected writes to succeed after a failed rotation: %v", err)
+	}
+}
+
+func TestRotatingFilePrunesOldBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	old := filepath.Join(dir, "app-"+time.Now().Add(-72*time.Hour).Format(backupTimeFormat)+".log")
+	if err := os.WriteFile(old, []byte("old\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := newRotatingFile(&rotationConfig{path: path, maxAgeDays: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defer r.Close()
+
+	if err := r.rotate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fileExists(old) {
+		t.Errorf("expected %s to be pruned", old)
+	}
+}
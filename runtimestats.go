@@ -0,0 +1,50 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "runtime"
+
+const (
+	// GoroutinesKey is the key that has the number of goroutines as value (see
+	// WithRuntimeStats).
+	GoroutinesKey = "goroutines"
+	// HeapAllocKey is the key that has the bytes of allocated heap objects as value (see
+	// WithRuntimeStats).
+	HeapAllocKey = "heap_alloc_bytes"
+)
+
+// readMemStats reads the memory statistics; tests replace it.
+var readMemStats = runtime.ReadMemStats
+
+// WithRuntimeStats adds the current number of goroutines and of bytes of allocated heap
+// objects to the log record, eg. to diagnose memory issues. They're only read if the record is
+// written (see WithLazy), since reading memory statistics stops the world briefly.
+func WithRuntimeStats() Option {
+	goroutines := WithLazy(GoroutinesKey, func() any {
+		return runtime.NumGoroutine()
+	})
+
+	heapAlloc := WithLazy(HeapAllocKey, func() any {
+		var stats runtime.MemStats
+		readMemStats(&stats)
+
+		return stats.HeapAlloc
+	})
+
+	return func(o *options) {
+		goroutines(o)
+		heapAlloc(o)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestWithRuntimeStats(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	Info(ctx, "x", WithRuntimeStats())
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	if n, _ := records[0][GoroutinesKey].(float64); n < 1 {
+		t.Errorf("expected at least one goroutine, got %v", records[0][GoroutinesKey])
+	}
+
+	if n, _ := records[0][HeapAllocKey].(float64); n <= 0 {
+		t.Errorf("expected allocated heap objects, got %v", records[0][HeapAllocKey])
+	}
+}
+
+func TestWithRuntimeStatsLazy(t *testing.T) {
+	var reads int
+
+	defer func(read func(*runtime.MemStats)) { readMemStats = read }(readMemStats)
+
+	readMemStats = func(*runtime.MemStats) {
+		reads++
+	}
+
+	ctx, _ := newFileContext(t)
+
+	Debug(ctx, "x", WithRuntimeStats())
+	Info(ctx, "x", WithRuntimeStats(), WithSkip(true))
+
+	if reads != 0 {
+		t.Errorf("expected no reads for dropped records, got %d", reads)
+	}
+
+	Info(ctx, "x", WithRuntimeStats())
+
+	if reads != 1 {
+		t.Errorf("expected a read for the written record, got %d", reads)
+	}
+}
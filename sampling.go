@@ -0,0 +1,159 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithSamplingHook decides per entry whether it's written: fn returns zapcore.LogSampled to
+// write the entry or zapcore.LogDropped to drop it. For example, to keep every record at
+// ErrorLevel and above but only one in a hundred Debug records:
+//
+//	var n atomic.Uint64
+//
+//	clog.WithSamplingHook(func(entry zapcore.Entry) zapcore.SamplingDecision {
+//		if entry.Level == zapcore.DebugLevel && n.Add(1)%100 != 1 {
+//			return zapcore.LogDropped
+//		}
+//
+//		return zapcore.LogSampled
+//	})
+//
+// fn is called for every enabled entry, possibly concurrently, so it must be cheap and safe
+// for concurrent use. Dropped entries don't reach hooks registered with WithHooks.
+//
+// Unlike zapcore.SamplerHook, which only observes the decisions of zap's sampler, fn makes
+// the decision.
+func WithSamplingHook(fn func(entry zapcore.Entry) zapcore.SamplingDecision) ContextOption {
+	return func(o *contextOptions) {
+		o.sampling = fn
+	}
+}
+
+// samplingCore drops the entries its decide function rejects.
+type samplingCore struct {
+	zapcore.Core
+	decide  func(zapcore.Entry) zapcore.SamplingDecision
+	explain zapcore.WriteSyncer // see WithExplain
+}
+
+func (c *samplingCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+// Write decides whether to drop the entry, rather than Check, because the cores wrapping it
+// add themselves to the checked entry without consulting their wrapped core's Check.
+func (c *samplingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.decide(entry)&zapcore.LogDropped != 0 {
+		explainTo(c.explain, entry.Level, entry.Message, reasonSampled)
+
+		return nil
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{
+		Core:    c.Core.With(fields),
+		decide:  c.decide,
+		explain: c.explain,
+	}
+}
+
+// WithLevelSampling samples records at or below level the way zap's sampler does: of the
+// records with the same level and message, the first initial of every second are logged and
+// then every thereafter-th one. Records above level are always logged, eg. to keep every
+// warning and error while sampling Debug and Info records in a hot loop.
+//
+// Changing the level of the logging context (see SetLevel) resets the sampler, as does
+// ResetSampler, so that eg. the first Debug records logged after lowering the level for live
+// debugging aren't sampled away because of those logged before.
+func WithLevelSampling(level Level, initial, thereafter int) ContextOption {
+	return func(o *contextOptions) {
+		o.levelSampling = newLevelSampler(zapcore.Level(level), initial, thereafter)
+	}
+}
+
+// ResetSampler resets the state of the sampler of the logging context (see WithLevelSampling),
+// as if no record had been logged yet. The sampler is shared by the contexts derived from the
+// same logging context.
+//
+// If ctx is not a logging context, or doesn't sample records, then this is a no-op.
+func ResetSampler(ctx context.Context) {
+	if s, ok := ctx.Value(samplerKey).(*levelSampler); ok {
+		s.reset()
+	}
+}
+
+// levelSampler decides whether to log entries at or below level with a zap sampler, and logs
+// all the others.
+type levelSampler struct {
+	level      zapcore.Level
+	initial    int
+	thereafter int
+	sampler    atomic.Pointer[zapcore.Core]
+}
+
+func newLevelSampler(level zapcore.Level, initial, thereafter int) *levelSampler {
+	s := &levelSampler{level: level, initial: initial, thereafter: thereafter}
+	s.reset()
+
+	return s
+}
+
+// reset replaces the sampler with a new one, which has no state.
+func (s *levelSampler) reset() {
+	sampler := zapcore.NewSamplerWithOptions(probeCore{}, time.Second, s.initial, s.thereafter)
+	s.sampler.Store(&sampler)
+}
+
+func (s *levelSampler) decide(entry zapcore.Entry) zapcore.SamplingDecision {
+	if entry.Level > s.level || (*s.sampler.Load()).Check(entry, nil) != nil {
+		return zapcore.LogSampled
+	}
+
+	return zapcore.LogDropped
+}
+
+// probeCore accepts every entry without writing it, to learn the decisions of a sampler.
+type probeCore struct{}
+
+func (probeCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c probeCore) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+func (c probeCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(entry, c)
+}
+
+func (probeCore) Write(zapcore.Entry, []zapcore.Field) error {
+	return nil
+}
+
+func (probeCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithSamplingHook(t *testing.T) {
+	var debugs int
+
+	hook, entries := recorder()
+
+	ctx, read := newFileContext(t,
+		WithLevel(DebugLevel),
+		WithHooks(hook),
+		WithSamplingHook(func(entry zapcore.Entry) zapcore.SamplingDecision {
+			if entry.Level == zapcore.DebugLevel {
+				debugs++
+
+				if debugs%2 == 0 {
+					return zapcore.LogDropped
+				}
+			}
+
+			return zapcore.LogSampled
+		}),
+	)
+
+	ctx = ContextWithField(ctx, "a", 1)
+
+	for range 4 {
+		Debug(ctx, "debug")
+		Error(ctx, "error")
+	}
+
+	records := read()
+	requireRecords(t, records, 6)
+
+	var kept int
+
+	for _, r := range records {
+		if r["msg"] == "debug" {
+			kept++
+		}
+	}
+
+	if kept != 2 {
+		t.Errorf("expected every other debug record, got %d", kept)
+	}
+
+	if len(*entries) != 6 {
+		t.Errorf("expected hooks to only see the kept entries, got %d", len(*entries))
+	}
+}
+
+func TestWithLevelSampling(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(DebugLevel), WithLevelSampling(InfoLevel, 10, 5))
+
+	for range 100 {
+		Debug(ctx, "debug")
+		Info(ctx, "info")
+		Error(ctx, "error")
+	}
+
+	counts := map[any]int{}
+
+	for _, r := range read() {
+		counts[r["msg"]]++
+	}
+
+	// the first 10 and then every 5th of the remaining 90
+	if want := 10 + 90/5; counts["debug"] != want || counts["info"] != want {
+		t.Errorf("expected %d debug and info records, got %v", want, counts)
+	}
+
+	if counts["error"] != 100 {
+		t.Errorf("expected every error record, got %d", counts["error"])
+	}
+}
+
+func TestWithLevelSamplingReset(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevelSampling(InfoLevel, 3, 1000))
+
+	logMany := func(msg string) {
+		for range 10 {
+			Info(ctx, msg)
+			Debug(ctx, msg)
+		}
+	}
+
+	logMany("before")
+
+	SetLevel(ctx, DebugLevel)
+	logMany("after")
+
+	// not a level change
+	SetLevel(ctx, DebugLevel)
+	logMany("after")
+
+	ResetSampler(ctx)
+	logMany("reset")
+
+	counts := map[string]int{}
+
+	for _, r := range read() {
+		counts[r["msg"].(string)+" "+r["severity"].(string)]++
+	}
+
+	want := map[string]int{
+		"before INFO": 3,
+		"after INFO":  3,
+		"after DEBUG": 3,
+		"reset INFO":  3,
+		"reset DEBUG": 3,
+	}
+
+	for k, n := range want {
+		if counts[k] != n {
+			t.Errorf("expected %d %q records, got %d", n, k, counts[k])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"fmt"
+	"io"
+)
+
+// RedactedValue is what values wrapped with Secret are logged as.
+const RedactedValue = "***"
+
+// Secret wraps v so that it's logged as RedactedValue whatever its key, eg.
+// WithField("token", clog.Secret(token)), to mark sensitive values where they are logged
+// rather than by key. The wrapped value is never written: it's also redacted when nested in
+// another value (eg. a map) and when formatted with the fmt package.
+func Secret(v any) any {
+	return secret{value: v}
+}
+
+// secret is a value wrapped with Secret. It's logged with String, by zap, and MarshalJSON,
+// when nested in a value encoded with reflection.
+type secret struct {
+	value any
+}
+
+func (secret) String() string {
+	return RedactedValue
+}
+
+func (secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + RedactedValue + `"`), nil
+}
+
+func (secret) MarshalText() ([]byte, error) {
+	return []byte(RedactedValue), nil
+}
+
+// Format prints RedactedValue for every verb, including %#v and %+v which would otherwise
+// print the wrapped value.
+func (secret) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, RedactedValue)
+}
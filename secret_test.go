@@ -0,0 +1,59 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	const token = "s3cr3t-t0k3n"
+
+	for _, encoding := range []ContextOption{WithJSONEncoding(), WithConsoleEncoding()} {
+		ctx, read := newRawContext(t, encoding)
+		ctx = ContextWithField(ctx, "session", Secret(token))
+
+		Info(ctx, "login",
+			WithField("token", Secret(token)),
+			WithField("nested", map[string]any{"password": Secret(token), "user": "bob"}),
+			WithField("list", []any{Secret(token)}),
+		)
+
+		out := read()
+		if strings.Contains(out, token) {
+			t.Fatalf("the secret was written: %s", out)
+		}
+
+		if got := strings.Count(out, RedactedValue); got != 4 {
+			t.Errorf("expected 4 redacted values, got %d: %s", got, out)
+		}
+
+		if !strings.Contains(out, "bob") {
+			t.Errorf("expected the other values: %s", out)
+		}
+	}
+}
+
+func TestSecretFormat(t *testing.T) {
+	s := Secret("hunter2")
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+		if got := fmt.Sprintf(verb, s); got != RedactedValue {
+			t.Errorf("%s: expected %s, got %s", verb, RedactedValue, got)
+		}
+	}
+}
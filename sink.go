@@ -0,0 +1,36 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"net/url"
+
+	"go.uber.org/zap"
+)
+
+// RegisterSink registers factory to open output paths with the given URL scheme, so that
+// logging contexts can write to custom destinations:
+//
+//	err := clog.RegisterSink("kafka", func(u *url.URL) (zap.Sink, error) {
+//		return newKafkaSink(u.Host) // u.Host is the topic of "kafka://events"
+//	})
+//	// ...
+//	ctx, err := clog.NewContext(nil, clog.WithOutputPath("kafka://events"))
+//
+// Sinks are registered process-wide (with zap.RegisterSink); registering a scheme twice is
+// an error.
+func RegisterSink(scheme string, factory func(*url.URL) (zap.Sink, error)) error {
+	return zap.RegisterSink(scheme, factory)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// memorySink is an in-memory zap.Sink.
+type memorySink struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (s *memorySink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.Write(p)
+}
+
+func (*memorySink) Sync() error {
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	return nil
+}
+
+func TestRegisterSink(t *testing.T) {
+	sinks := map[string]*memorySink{}
+
+	err := RegisterSink("memory", func(u *url.URL) (zap.Sink, error) {
+		s := &memorySink{}
+		sinks[u.Host] = s
+
+		return s, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to register sink: %v", err)
+	}
+
+	if err := RegisterSink("memory", nil); err == nil {
+		t.Error("expected an error when registering a scheme twice")
+	}
+
+	ctx, err := NewContext(context.Background(),
+		WithJSONEncoding(), WithNoTimeKey(), WithOutputPath("memory://events"),
+	)
+	if err != nil {
+		t.Fatalf("failed to create logging context: %v", err)
+	}
+
+	Info(ctx, "x")
+
+	if err := Close(ctx); err != nil {
+		t.Fatalf("failed to close logging context: %v", err)
+	}
+
+	s := sinks["events"]
+	if s == nil {
+		t.Fatal("expected the sink to be opened")
+	}
+
+	if got, want := s.buf.String(), `{"severity":"INFO","msg":"x"}`+"\n"; got != want || !s.closed {
+		t.Errorf("expected %q written to a closed sink, got %q (closed: %v)", want, got, s.closed)
+	}
+}
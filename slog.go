@@ -0,0 +1,156 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler is a slog.Handler that writes through a logging context.
+type slogHandler struct {
+	ctx    context.Context
+	fields Fields
+	prefix string
+}
+
+// NewSlogHandler returns a slog.Handler that writes records through the logging context
+// ctx, so that slog.New(clog.NewSlogHandler(ctx)) shares its configuration and level.
+//
+// slog levels are mapped to the closest clog level at or below them (eg. slog.LevelWarn+1 is
+// logged at WarnLevel), attributes become fields and groups become dotted key prefixes.
+//
+// If ctx is not a logging context then the handler discards all records.
+func NewSlogHandler(ctx context.Context) slog.Handler {
+	return &slogHandler{ctx: ctx}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	b, ok := backendOf(h.ctx)
+	if !ok {
+		return false
+	}
+
+	return b.Enabled(fromSlogLevel(level))
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	b, ok := backendOf(h.ctx)
+	if !ok {
+		return nil
+	}
+
+	level := fromSlogLevel(r.Level)
+
+	write := func(zf []zap.Field) {
+		writeZap(b, level, r.Message, zf)
+	}
+
+	if zb, ok := b.(*zapBackend); ok {
+		// zap's entries can be given the record's time
+		checked := zb.logger.Check(zapcore.Level(level), r.Message)
+		if checked == nil {
+			return nil
+		}
+
+		if !r.Time.IsZero() {
+			checked.Time = r.Time
+		}
+
+		write = func(zf []zap.Field) {
+			checked.Write(zf...)
+		}
+	} else if !b.Enabled(level) {
+		return nil
+	}
+
+	fields := make(Fields, len(h.fields)+r.NumAttrs())
+
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		addSlogAttr(fields, h.prefix, a)
+
+		return true
+	})
+
+	zf, _ := getFields(h.ctx, []Option{WithFields(fields)})
+
+	write(zf)
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(Fields, len(h.fields)+len(attrs))
+
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+
+	for _, a := range attrs {
+		addSlogAttr(fields, h.prefix, a)
+	}
+
+	return &slogHandler{ctx: h.ctx, fields: fields, prefix: h.prefix}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &slogHandler{ctx: h.ctx, fields: h.fields, prefix: h.prefix + name + "."}
+}
+
+func addSlogAttr(fields Fields, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+
+		for _, ga := range a.Value.Group() {
+			addSlogAttr(fields, prefix, ga)
+		}
+
+		return
+	}
+
+	if a.Key == "" {
+		return
+	}
+
+	fields[prefix+a.Key] = a.Value.Any()
+}
+
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
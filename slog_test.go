@@ -0,0 +1,90 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	ctx, read := newFileContext(t, WithTimeKey("time"))
+
+	logger := slog.New(NewSlogHandler(ctx)).With("service", "api").WithGroup("http")
+
+	logger.Debug("hidden")
+	logger.Info("request", "method", "GET", slog.Group("resp", "status", 200))
+	logger.Log(context.Background(), slog.LevelWarn+1, "slow")
+	logger.Error("failed", slog.Group("", "inline", true))
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	r := records[0]
+
+	want := map[string]any{
+		"severity":         "INFO",
+		"msg":              "request",
+		"service":          "api",
+		"http.method":      "GET",
+		"http.resp.status": 200.0,
+	}
+
+	for k, v := range want {
+		if r[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, r[k])
+		}
+	}
+
+	if _, err := time.Parse(time.RFC3339, r["time"].(string)); err != nil {
+		t.Errorf("unexpected time: %v", err)
+	}
+
+	if records[1]["severity"] != "WARN" {
+		t.Errorf("expected WARN+1 to map to WARN, got %v", records[1])
+	}
+
+	if records[2]["severity"] != "ERROR" || records[2]["http.inline"] != true {
+		t.Errorf("unexpected record %v", records[2])
+	}
+}
+
+func TestSlogHandlerLevel(t *testing.T) {
+	ctx, _ := newFileContext(t)
+
+	h := NewSlogHandler(ctx)
+
+	if h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("expected Debug to be disabled")
+	}
+
+	SetLevel(ctx, DebugLevel)
+
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("expected Debug to be enabled after SetLevel")
+	}
+}
+
+func TestSlogHandlerNotLoggingContext(t *testing.T) {
+	h := NewSlogHandler(context.Background())
+
+	if h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("expected all levels to be disabled")
+	}
+
+	slog.New(h).Error("discarded")
+}
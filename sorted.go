@@ -0,0 +1,55 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"slices"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithSortedFields emits the fields of every record sorted by key, context fields included,
+// for deterministic, diff-friendly output (eg. golden files). Fields nested in a namespace
+// (see WithNamespace) are sorted within it.
+func WithSortedFields() ContextOption {
+	return func(o *contextOptions) {
+		o.sortFields = true
+	}
+}
+
+// sortFields sorts fields by key in place, keeping the order of fields with the same key.
+// Each namespace is sorted separately: a zap.Namespace field stays after the fields of the
+// enclosing namespace, since the fields following it are nested.
+func sortFields(fields []zapcore.Field) []zapcore.Field {
+	start := 0
+
+	for i := range fields {
+		if fields[i].Type == zapcore.NamespaceType {
+			sortByKey(fields[start:i])
+			start = i + 1
+		}
+	}
+
+	sortByKey(fields[start:])
+
+	return fields
+}
+
+func sortByKey(fields []zapcore.Field) {
+	slices.SortStableFunc(fields, func(a, b zapcore.Field) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+}
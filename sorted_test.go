@@ -0,0 +1,54 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithSortedFields(t *testing.T) {
+	want := `{"severity":"INFO","msg":"x","a":1,"b":2,"c":3,"n":{"y":5,"z":4}}` + "\n"
+
+	for range 3 {
+		ctx, read := newRawContext(t, WithJSONEncoding(), WithSortedFields())
+		ctx = ContextWithField(ctx, "c", 3)
+		ctx = ContextWithField(ctx, "a", 1)
+
+		Info(ctx, "x", WithField("b", 2), WithNamespace("n"), WithFields(Fields{"z": 4, "y": 5}))
+
+		if got := read(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestSortFields(t *testing.T) {
+	got := sortFields([]zapcore.Field{
+		zap.Int("b", 1), zap.Int("a", 2), zap.Namespace("n"), zap.Int("d", 3), zap.Int("c", 4),
+	})
+
+	want := []zapcore.Field{
+		zap.Int("a", 2), zap.Int("b", 1), zap.Namespace("n"), zap.Int("c", 4), zap.Int("d", 3),
+	}
+
+	for i := range want {
+		if !got[i].Equals(want[i]) {
+			t.Errorf("expected %v at %d, got %v", want[i], i, got[i])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap/zapcore"
+
+// splitCore routes entries below a level to one core and the rest to another. Unlike a tee,
+// the routing is done by Write too, so it holds even when the level check is bypassed (see
+// ContextWithIndependentLevel).
+type splitCore struct {
+	below zapcore.Core
+	above zapcore.Core
+	split zapcore.Level
+}
+
+func (c *splitCore) pick(level zapcore.Level) zapcore.Core {
+	if level < c.split {
+		return c.below
+	}
+
+	return c.above
+}
+
+func (c *splitCore) Enabled(level zapcore.Level) bool {
+	return c.pick(level).Enabled(level)
+}
+
+func (c *splitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &splitCore{
+		below: c.below.With(fields),
+		above: c.above.With(fields),
+		split: c.split,
+	}
+}
+
+func (c *splitCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return c.pick(entry.Level).Check(entry, checked)
+}
+
+func (c *splitCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.pick(entry.Level).Write(entry, fields)
+}
+
+func (c *splitCore) Sync() error {
+	if err := c.below.Sync(); err != nil {
+		return err
+	}
+
+	return c.above.Sync()
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureStd redirects os.Stdout and os.Stderr while fn runs and returns what was written
+// to each.
+func captureStd(t *testing.T, fn func()) (stdout, stderr string) {
+	t.Helper()
+
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	origOut, origErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+
+	defer func() {
+		os.Stdout, os.Stderr = origOut, origErr
+	}()
+
+	outC := make(chan string)
+	errC := make(chan string)
+
+	go func() {
+		b, _ := io.ReadAll(outR)
+		outC <- string(b)
+	}()
+
+	go func() {
+		b, _ := io.ReadAll(errR)
+		errC <- string(b)
+	}()
+
+	fn()
+
+	_ = outW.Close()
+	_ = errW.Close()
+
+	return <-outC, <-errC
+}
+
+func TestWithLevelSplitOutput(t *testing.T) {
+	stdout, stderr := captureStd(t, func() {
+		ctx := Context(context.Background(), WithLevelSplitOutput(), WithLevel(DebugLevel))
+
+		Debug(ctx, "debug line")
+		Info(ctx, "info line")
+		Warn(ctx, "warn line")
+		Error(ctx, "error line")
+	})
+
+	for _, msg := range []string{"debug line", "info line"} {
+		if !strings.Contains(stdout, msg) || strings.Contains(stderr, msg) {
+			t.Errorf("expected %q only on stdout; stdout=%q stderr=%q", msg, stdout, stderr)
+		}
+	}
+
+	for _, msg := range []string{"warn line", "error line"} {
+		if !strings.Contains(stderr, msg) || strings.Contains(stdout, msg) {
+			t.Errorf("expected %q only on stderr; stdout=%q stderr=%q", msg, stdout, stderr)
+		}
+	}
+}
+
+func TestWithStdoutBelowRespectsLevel(t *testing.T) {
+	stdout, _ := captureStd(t, func() {
+		ctx := Context(context.Background(), WithStdoutBelow(ErrorLevel))
+
+		Debug(ctx, "debug line")
+		Info(ctx, "info line")
+	})
+
+	if strings.Contains(stdout, "debug line") || !strings.Contains(stdout, "info line") {
+		t.Errorf("unexpected stdout: %q", stdout)
+	}
+}
+
+func TestWithStdoutBelowConflicts(t *testing.T) {
+	for name, opt := range map[string]ContextOption{
+		"stdout":   OutputToStdout(),
+		"rotating": WithRotatingFile(t.TempDir()+"/app.log", 0, 0, 0),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewContext(context.Background(), WithLevelSplitOutput(), opt)
+			if err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
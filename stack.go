@@ -0,0 +1,45 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// StacktraceKey is the key that has the stack trace of PanicLevel and FatalLevel records as
+// value.
+const StacktraceKey = "stacktrace"
+
+// WithStackTraceSkip trims n additional frames from the top of the stack traces of PanicLevel
+// and FatalLevel records, so that helper functions wrapping clog's don't appear in them. The
+// stack traces already start at the caller of the logging function.
+func WithStackTraceSkip(n int) ContextOption {
+	return func(o *contextOptions) {
+		o.record.stackSkip += n
+	}
+}
+
+// stacktrace returns the stack trace of the logging function's caller, which calls it.
+func stacktrace(ctx context.Context) zap.Field {
+	skip := 2
+
+	if rc, ok := ctx.Value(recordKey).(*recordConfig); ok {
+		skip += rc.stackSkip
+	}
+
+	return zap.StackSkip(StacktraceKey, skip)
+}
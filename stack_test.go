@@ -0,0 +1,68 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// failHard wraps Panic like an application helper would.
+func failHard(ctx context.Context, msg string) {
+	Panic(ctx, msg)
+}
+
+func TestWithStackTraceSkip(t *testing.T) {
+	ctx, read := newFileContext(t, WithStackTraceSkip(1))
+
+	func() {
+		defer func() { _ = recover() }()
+
+		failHard(ctx, "boom")
+	}()
+
+	records := read()
+	requireRecords(t, records, 1)
+
+	stack, _ := records[0][StacktraceKey].(string)
+
+	var funcs []string
+
+	for _, line := range strings.Split(stack, "\n") {
+		if line != "" && !strings.HasPrefix(line, "\t") {
+			funcs = append(funcs, line)
+		}
+	}
+
+	const caller = "github.com/terminalstream/clog.TestWithStackTraceSkip"
+
+	if len(funcs) == 0 || !strings.HasPrefix(funcs[0], caller) {
+		t.Fatalf("expected the stack trace to start at the helper's caller, got %q", stack)
+	}
+
+	for _, fn := range funcs {
+		for _, internal := range []string{
+			"github.com/terminalstream/clog.failHard",
+			"github.com/terminalstream/clog.Panic",
+			"github.com/terminalstream/clog.stacktrace",
+			"go.uber.org/zap",
+		} {
+			if strings.HasPrefix(fn, internal) {
+				t.Errorf("unexpected frame %q", fn)
+			}
+		}
+	}
+}
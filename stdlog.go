@@ -0,0 +1,41 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// StdLogger returns a standard library *log.Logger that writes each line through the logging
+// context ctx at the given level. The logger has no prefix or flags, since timestamps are
+// added by the logging context.
+func StdLogger(ctx context.Context, level Level) *log.Logger {
+	// the records are annotated with the caller of the *log.Logger's method (see WithCaller),
+	// which calls stdWriter.Write through log.(*Logger).output
+	return log.New(&stdWriter{ctx: skipCallers(ctx, 3), level: level}, "", 0)
+}
+
+type stdWriter struct {
+	ctx   context.Context
+	level Level
+}
+
+func (w *stdWriter) Write(p []byte) (int, error) {
+	Log(w.ctx, w.level, strings.TrimSuffix(string(p), "\n"))
+
+	return len(p), nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "testing"
+
+func TestStdLogger(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	logger := StdLogger(ctx, WarnLevel)
+
+	logger.Printf("legacy %d", 42)
+	logger.Print("no newline")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	if records[0]["msg"] != "legacy 42" || records[0]["severity"] != "WARN" {
+		t.Errorf("unexpected record %v", records[0])
+	}
+
+	if records[1]["msg"] != "no newline" {
+		t.Errorf("unexpected record %v", records[1])
+	}
+}
+
+func TestStdLoggerRespectsLevel(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	StdLogger(ctx, DebugLevel).Print("hidden")
+
+	requireRecords(t, read(), 0)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap/zapcore"
+
+// WithSyncOnError flushes the output after every record at ErrorLevel or above, so the last
+// error survives a subsequent crash. Records at lower levels are not flushed.
+//
+// Flushing an *os.File calls fsync, which can take milliseconds; don't enable it for
+// contexts that log errors at a high rate.
+func WithSyncOnError() ContextOption {
+	return func(o *contextOptions) {
+		o.syncOnError = true
+	}
+}
+
+// syncOnErrorCore syncs the wrapped core after writing entries at ErrorLevel or above.
+type syncOnErrorCore struct {
+	zapcore.Core
+}
+
+func (c *syncOnErrorCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *syncOnErrorCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if err := c.Core.Write(entry, fields); err != nil {
+		return err
+	}
+
+	if entry.Level < zapcore.ErrorLevel {
+		return nil
+	}
+
+	return c.Core.Sync()
+}
+
+func (c *syncOnErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &syncOnErrorCore{Core: c.Core.With(fields)}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type countingSyncer struct {
+	io.Writer
+	syncs int
+}
+
+func (s *countingSyncer) Sync() error {
+	s.syncs++
+
+	return nil
+}
+
+func TestSyncOnErrorCore(t *testing.T) {
+	syncer := &countingSyncer{Writer: io.Discard}
+
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), syncer, zapcore.DebugLevel,
+	)
+
+	b := &zapBackend{logger: zap.New(&syncOnErrorCore{Core: core})}
+
+	ctx := context.WithValue(context.Background(), loggerKey, b)
+	ctx = ContextWithField(ctx, "a", 1)
+
+	Debug(ctx, "x")
+	Info(ctx, "x")
+	Warn(ctx, "x")
+
+	if syncer.syncs != 0 {
+		t.Fatalf("expected no sync below ErrorLevel, got %d", syncer.syncs)
+	}
+
+	Error(ctx, "x")
+	Log(ctx, ErrorLevel, "x")
+
+	if syncer.syncs != 2 {
+		t.Errorf("expected one sync per error entry, got %d", syncer.syncs)
+	}
+}
+
+func TestWithSyncOnError(t *testing.T) {
+	ctx, read := newFileContext(t, WithSyncOnError())
+
+	Error(ctx, "x")
+
+	if r := read(); len(r) != 1 || r[0]["msg"] != "x" {
+		t.Errorf("expected the error record, got %v", r)
+	}
+}
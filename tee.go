@@ -0,0 +1,193 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithTeeCore additionally writes every record to core, eg. to mirror the logs into a
+// RingBufferCore. The records are passed to core regardless of the logging context's level,
+// but only at the levels core enables: core keeps its own level, even for forced records (see
+// WithForce) and contexts with an independent level. A level is enabled (see Enabled) if
+// either the logging context or core enables it.
+func WithTeeCore(core zapcore.Core) ContextOption {
+	return func(o *contextOptions) {
+		o.teeCores = append(o.teeCores, &teeCore{Core: core})
+	}
+}
+
+// teeCore filters the entries written to a core added with WithTeeCore by its level, since the
+// cores wrapping the tee write to it without checking it (see checkEnabled).
+type teeCore struct {
+	zapcore.Core
+}
+
+func (c *teeCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.Enabled(entry.Level) {
+		return nil
+	}
+
+	return c.Core.Write(entry, fields)
+}
+
+func (c *teeCore) With(fields []zapcore.Field) zapcore.Core {
+	return &teeCore{Core: c.Core.With(fields)}
+}
+
+// RingBufferCore is a zapcore.Core that retains the last entries written to it, JSON encoded,
+// eg. to tail the logs from an admin endpoint:
+//
+//	tail := clog.NewRingBufferCore(1000, zapcore.DebugLevel)
+//	ctx := clog.Context(nil, clog.WithTeeCore(tail))
+//
+//	http.HandleFunc("/debug/logs", func(w http.ResponseWriter, _ *http.Request) {
+//		_ = json.NewEncoder(w).Encode(tail)
+//	})
+type RingBufferCore struct {
+	zapcore.LevelEnabler
+	enc  zapcore.Encoder
+	ring *lineRing
+}
+
+// NewRingBufferCore returns a RingBufferCore retaining the last capacity entries enabled by
+// level.
+func NewRingBufferCore(capacity int, level zapcore.LevelEnabler) *RingBufferCore {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  DefaultMessageKey,
+		LevelKey:    DefaultLevelKey,
+		TimeKey:     DefaultTimeKey,
+		EncodeTime:  zapcore.RFC3339TimeEncoder,
+		EncodeLevel: zapcore.CapitalLevelEncoder,
+	})
+
+	return newRingBufferCore(enc, level, capacity)
+}
+
+func newRingBufferCore(
+	enc zapcore.Encoder, level zapcore.LevelEnabler, capacity int,
+) *RingBufferCore {
+	return &RingBufferCore{
+		LevelEnabler: level,
+		enc:          enc,
+		ring:         &lineRing{lines: make([]string, 0, max(capacity, 0))},
+	}
+}
+
+// Lines returns the retained entries, oldest first.
+func (c *RingBufferCore) Lines() []string {
+	return c.ring.snapshot()
+}
+
+// MarshalJSON encodes the retained entries as an array, oldest first.
+func (c *RingBufferCore) MarshalJSON() ([]byte, error) {
+	lines := c.Lines()
+	entries := make([]json.RawMessage, len(lines))
+
+	for i := range lines {
+		entries[i] = json.RawMessage(lines[i])
+	}
+
+	return json.Marshal(entries)
+}
+
+func (c *RingBufferCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+
+	return &RingBufferCore{LevelEnabler: c.LevelEnabler, enc: enc, ring: c.ring}
+}
+
+func (c *RingBufferCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *RingBufferCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+
+	c.ring.add(strings.TrimSuffix(buf.String(), "\n"))
+	buf.Free()
+
+	return nil
+}
+
+func (*RingBufferCore) Sync() error {
+	return nil
+}
+
+// lineRing retains the last cap(lines) lines added to it.
+type lineRing struct {
+	mu    sync.Mutex
+	lines []string
+	next  int // the index of the oldest line once lines is full
+}
+
+func (r *lineRing) add(line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.lines) < cap(r.lines) {
+		r.lines = append(r.lines, line)
+
+		return
+	}
+
+	if len(r.lines) == 0 {
+		return
+	}
+
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+}
+
+func (r *lineRing) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append(slices.Clone(r.lines[r.next:]), r.lines[:r.next]...)
+}
+
+// WithMemoryTail retains the last capacity records of the logging context in memory, encoded
+// like the records written to its output; Tail returns them, eg. for a /debug/logs endpoint.
+func WithMemoryTail(capacity int) ContextOption {
+	return func(o *contextOptions) {
+		o.tailCapacity = capacity
+	}
+}
+
+// Tail returns the last records of the logging context ctx, oldest first, without line
+// endings. It returns nil if ctx is not a logging context created with WithMemoryTail.
+func Tail(ctx context.Context) []string {
+	tail, ok := ctx.Value(tailKey).(*RingBufferCore)
+	if !ok {
+		return nil
+	}
+
+	return tail.Lines()
+}
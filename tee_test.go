@@ -0,0 +1,158 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithTeeCore(t *testing.T) {
+	tail := NewRingBufferCore(2, zapcore.DebugLevel)
+
+	ctx, read := newFileContext(t, WithTeeCore(tail))
+	ctx = ContextWithField(ctx, "a", 1)
+
+	for i := range 3 {
+		Info(ctx, fmt.Sprint(i))
+	}
+
+	requireRecords(t, read(), 3)
+
+	b, err := json.Marshal(tail)
+	if err != nil {
+		t.Fatalf("failed to marshal the tail: %v", err)
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal(b, &entries); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+
+	if len(entries) != 2 || entries[0]["msg"] != "1" || entries[1]["msg"] != "2" ||
+		entries[1]["a"] != 1.0 {
+		t.Errorf("expected the last 2 entries, got %v", entries)
+	}
+}
+
+func TestRingBufferCoreLevel(t *testing.T) {
+	tail := NewRingBufferCore(10, zapcore.DebugLevel)
+
+	ctx, read := newFileContext(t, WithTeeCore(tail))
+
+	Debug(ctx, "x")
+
+	if got := len(tail.Lines()); got != 1 {
+		t.Errorf("expected the tee'd core to filter by its own level, got %d lines", got)
+	}
+
+	requireRecords(t, read(), 0)
+}
+
+func TestWithTeeCoreLevelWithWrappers(t *testing.T) {
+	tail := NewRingBufferCore(10, zapcore.ErrorLevel)
+
+	ctx, read := newFileContext(t,
+		WithTeeCore(tail),
+		WithHooks(func(zapcore.Entry, []zapcore.Field) {}),
+	)
+
+	Info(ctx, "info")
+	Debug(ctx, "forced", WithForce())
+	Debug(ContextWithIndependentLevel(ctx, DebugLevel), "independent")
+	Error(ctx, "error")
+
+	requireRecords(t, read(), 4)
+
+	if lines := tail.Lines(); len(lines) != 1 || !strings.Contains(lines[0], `"msg":"error"`) {
+		t.Errorf("expected only the error in the tee, got %v", lines)
+	}
+}
+
+func TestLineRing(t *testing.T) {
+	r := &lineRing{lines: make([]string, 0, 3)}
+
+	for i := range 5 {
+		r.add(fmt.Sprint(i))
+	}
+
+	if got, want := r.snapshot(), []string{"2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	empty := &lineRing{}
+	empty.add("x")
+
+	if got := empty.snapshot(); len(got) != 0 {
+		t.Errorf("expected nothing retained without capacity, got %v", got)
+	}
+}
+
+func TestWithMemoryTail(t *testing.T) {
+	ctx, _ := newFileContext(t, WithMemoryTail(3))
+	ctx = ContextWithField(ctx, "a", 1)
+
+	for i := range 5 {
+		Info(ctx, fmt.Sprint(i))
+	}
+
+	Debug(ctx, "below level")
+
+	want := []string{
+		`{"severity":"INFO","msg":"2","a":1}`,
+		`{"severity":"INFO","msg":"3","a":1}`,
+		`{"severity":"INFO","msg":"4","a":1}`,
+	}
+
+	if got := Tail(ctx); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if Tail(context.Background()) != nil {
+		t.Error("expected no tail for a plain context")
+	}
+}
+
+func TestWithMemoryTailConcurrent(t *testing.T) {
+	ctx, _ := newFileContext(t, WithMemoryTail(10))
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				Info(ctx, "x")
+				_ = Tail(ctx)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := len(Tail(ctx)); got != 10 {
+		t.Errorf("expected 10 lines, got %d", got)
+	}
+}
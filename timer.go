@@ -0,0 +1,39 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"time"
+)
+
+// ElapsedKey is the key that has the time elapsed since StartTimer was called as value.
+const ElapsedKey = "elapsed"
+
+// StartTimer starts measuring the time spent until the returned function is called, which
+// logs msg at InfoLevel with the elapsed duration and opts. It's meant to be deferred:
+//
+//	defer clog.StartTimer(ctx, "request handled")()
+//
+// Whether the record is enabled is decided when the returned function is called.
+func StartTimer(ctx context.Context, msg string) func(opts ...Option) {
+	start := time.Now()
+
+	return func(opts ...Option) {
+		Info(skipCallers(ctx, 1), msg,
+			append([]Option{WithField(ElapsedKey, time.Since(start))}, opts...)...,
+		)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartTimer(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	done := StartTimer(ctx, "done")
+
+	time.Sleep(time.Millisecond)
+
+	done(WithField("a", 1))
+
+	r := read()
+	requireRecords(t, r, 1)
+
+	if r[0]["msg"] != "done" || r[0]["a"] != 1.0 {
+		t.Errorf("unexpected record %v", r[0])
+	}
+
+	if elapsed, _ := r[0][ElapsedKey].(float64); elapsed < float64(time.Millisecond) {
+		t.Errorf("expected at least 1ms elapsed, got %v", r[0][ElapsedKey])
+	}
+}
+
+func TestStartTimerChecksLevelWhenDone(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(WarnLevel))
+
+	done := StartTimer(ctx, "done")
+
+	SetLevel(ctx, InfoLevel)
+	done()
+
+	requireRecords(t, read(), 1)
+
+	done = StartTimer(ctx, "done")
+
+	SetLevel(ctx, WarnLevel)
+	done()
+
+	requireRecords(t, read(), 1)
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "context"
+
+// WithTraceBasedDebug writes the records down to DebugLevel, whatever the level of the logging
+// context, when they're logged with a context whose trace is sampled, to keep full detail on
+// sampled traces only. sampled reports whether the trace of a context is sampled; eg. with
+// OpenTelemetry:
+//
+//	clog.WithTraceBasedDebug(func(ctx context.Context) bool {
+//		return trace.SpanContextFromContext(ctx).IsSampled()
+//	})
+//
+// sampled is only called for records below the level of the logging context.
+func WithTraceBasedDebug(sampled func(ctx context.Context) bool) ContextOption {
+	return func(o *contextOptions) {
+		o.record.traceSampled = sampled
+	}
+}
+
+// traceSampled reports whether a record at level is to be written because the trace of ctx is
+// sampled (see WithTraceBasedDebug).
+func traceSampled(ctx context.Context, level Level) bool {
+	rc, ok := ctx.Value(recordKey).(*recordConfig)
+
+	return ok && rc.traceSampled != nil && level >= DebugLevel && rc.traceSampled(ctx)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"testing"
+)
+
+// spanKey holds whether the trace of a context is sampled, like a tracing library would.
+type spanKey struct{}
+
+func TestWithTraceBasedDebug(t *testing.T) {
+	ctx, read := newFileContext(t, WithLevel(InfoLevel),
+		WithTraceBasedDebug(func(ctx context.Context) bool {
+			sampled, _ := ctx.Value(spanKey{}).(bool)
+
+			return sampled
+		}),
+	)
+
+	sampled := context.WithValue(ctx, spanKey{}, true)
+	unsampled := context.WithValue(ctx, spanKey{}, false)
+
+	Debug(sampled, "sampled")
+	Debug(unsampled, "unsampled")
+	Debug(ctx, "no trace")
+	Info(unsampled, "info")
+
+	records := read()
+	requireRecords(t, records, 2)
+
+	for i, want := range []string{"sampled", "info"} {
+		if records[i]["msg"] != want {
+			t.Errorf("expected %q, got %v", want, records[i])
+		}
+	}
+
+	if DebugEnabled(sampled) {
+		t.Error("expected the level of the logging context to be left as is")
+	}
+}
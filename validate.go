@@ -0,0 +1,93 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// FieldErrorKey is the key that has as value the keys of the fields whose values were
+	// replaced because they can't be encoded (see WithFieldValidation).
+	FieldErrorKey = "field_error"
+
+	invalidValue = "<invalid>"
+)
+
+// WithFieldValidation replaces field values that can't be encoded properly (NaN and infinite
+// floats, functions, channels and unsafe pointers) with "<invalid>", and
+// adds a "field_error" field with their keys (comma separated), so that one bad field doesn't
+// corrupt the whole record.
+func WithFieldValidation() ContextOption {
+	return func(o *contextOptions) {
+		o.validateFields = true
+	}
+}
+
+// validateFields replaces invalid field values in place.
+func validateFields(fields []zapcore.Field) []zapcore.Field {
+	var invalid []string
+
+	for i := range fields {
+		if !validField(fields[i]) {
+			invalid = append(invalid, fields[i].Key)
+			fields[i] = zap.String(fields[i].Key, invalidValue)
+		}
+	}
+
+	if len(invalid) == 0 {
+		return fields
+	}
+
+	// the marker goes before any namespace, which holds the fields that follow it
+	top := slices.IndexFunc(fields, func(f zapcore.Field) bool {
+		return f.Type == zapcore.NamespaceType
+	})
+	if top < 0 {
+		top = len(fields)
+	}
+
+	return slices.Insert(fields, top, zap.String(FieldErrorKey, strings.Join(invalid, ",")))
+}
+
+func validField(f zapcore.Field) bool {
+	switch f.Type {
+	case zapcore.Float64Type:
+		v := math.Float64frombits(uint64(f.Integer))
+
+		return !math.IsNaN(v) && !math.IsInf(v, 0)
+	case zapcore.Float32Type:
+		v := float64(math.Float32frombits(uint32(f.Integer)))
+
+		return !math.IsNaN(v) && !math.IsInf(v, 0)
+	case zapcore.ReflectType:
+		if f.Interface == nil {
+			return true
+		}
+
+		switch reflect.TypeOf(f.Interface).Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			return false
+		}
+	}
+
+	return true
+}
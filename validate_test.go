@@ -0,0 +1,48 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWithFieldValidation(t *testing.T) {
+	ctx, read := newRawContext(t, WithJSONEncoding(), WithFieldValidation())
+	ctx = ContextWithField(ctx, "ratio", math.NaN())
+
+	Info(ctx, "x", WithField("a", 1), WithField("fn", func() {}),
+		WithNamespace("n"), WithField("inf", math.Inf(1)),
+	)
+
+	want := `{"severity":"INFO","msg":"x","ratio":"<invalid>","a":1,"fn":"<invalid>",` +
+		`"field_error":"ratio,fn,inf","n":{"inf":"<invalid>"}}` + "\n"
+
+	if got := read(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithFieldValidationValidRecord(t *testing.T) {
+	ctx, read := newFileContext(t, WithFieldValidation())
+
+	Info(ctx, "x", WithField("a", 1.5), WithField("b", []int{1}), WithField("c", nil))
+
+	r := read()[0]
+
+	if _, ok := r[FieldErrorKey]; ok || r["a"] != 1.5 {
+		t.Errorf("expected a valid record to be untouched, got %v", r)
+	}
+}
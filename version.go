@@ -0,0 +1,39 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "go.uber.org/zap"
+
+const (
+	// VersionKey is the key that has the service's version as value (see WithVersion).
+	VersionKey = "version"
+	// CommitKey is the key that has the service's source commit as value.
+	CommitKey = "commit"
+)
+
+// WithVersion attaches "version" and "commit" fields with the service's version and source
+// commit to every log record, so that they're named the same across services. Empty values
+// are omitted, so they can be fed directly from build-time variables.
+func WithVersion(version, commit string) ContextOption {
+	return func(o *contextOptions) {
+		if version != "" {
+			o.fields = append(o.fields, zap.String(VersionKey, version))
+		}
+
+		if commit != "" {
+			o.fields = append(o.fields, zap.String(CommitKey, commit))
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import "testing"
+
+func TestWithVersion(t *testing.T) {
+	ctx, read := newFileContext(t, WithVersion("1.2.3", "abc123"))
+
+	Info(ctx, "x")
+
+	if r := read()[0]; r[VersionKey] != "1.2.3" || r[CommitKey] != "abc123" {
+		t.Errorf("expected version and commit, got %v", r)
+	}
+}
+
+func TestWithVersionOmitsEmptyValues(t *testing.T) {
+	ctx, read := newFileContext(t, WithVersion("1.2.3", ""))
+
+	Info(ctx, "x")
+
+	if _, ok := read()[0][CommitKey]; ok {
+		t.Error("expected an empty commit to be omitted")
+	}
+}
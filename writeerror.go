@@ -0,0 +1,69 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithWriteErrorHandler invokes fn when a record can't be written to the output (or to a core
+// added with WithTeeCore), eg. when a network sink is down, to fall back or alert. fn is
+// invoked with the error and the entry that wasn't written, in the goroutine that logged it.
+//
+// fn may log, eg. to another logging context, but errors writing records while fn is running
+// (in any goroutine) are dropped rather than handled, so that a handler logging to the failing
+// output doesn't recurse forever.
+func WithWriteErrorHandler(fn func(err error, entry zapcore.Entry)) ContextOption {
+	return func(o *contextOptions) {
+		o.writeErrorHandler = fn
+	}
+}
+
+// writeErrorCore invokes a handler with the errors returned by the wrapped core's Write.
+type writeErrorCore struct {
+	zapcore.Core
+	handle func(err error, entry zapcore.Entry)
+	// handling is set while handle runs, and shared by the cores derived through With
+	handling *atomic.Bool
+}
+
+func (c *writeErrorCore) Check(
+	entry zapcore.Entry, checked *zapcore.CheckedEntry,
+) *zapcore.CheckedEntry {
+	return checkEnabled(c, entry, checked)
+}
+
+func (c *writeErrorCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	err := c.Core.Write(entry, fields)
+	if err == nil || !c.handling.CompareAndSwap(false, true) {
+		return err
+	}
+
+	defer c.handling.Store(false)
+
+	c.handle(err, entry)
+
+	return err
+}
+
+func (c *writeErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &writeErrorCore{
+		Core:     c.Core.With(fields),
+		handle:   c.handle,
+		handling: c.handling,
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func (failingWriter) Sync() error {
+	return nil
+}
+
+func TestWithWriteErrorHandler(t *testing.T) {
+	failing := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), failingWriter{}, zap.DebugLevel,
+	)
+
+	var (
+		ctx     context.Context
+		handled []string
+	)
+
+	ctx, read := newFileContext(t,
+		WithTeeCore(failing),
+		WithWriteErrorHandler(func(err error, entry zapcore.Entry) {
+			handled = append(handled, entry.Message)
+
+			if err == nil {
+				t.Error("expected an error")
+			}
+
+			// this fails too, but mustn't recurse
+			Error(ctx, "failed to write a record", WithError(err))
+		}),
+	)
+
+	Info(ctx, "first")
+	Warn(ContextWithField(ctx, "k", "v"), "second")
+
+	if len(handled) != 2 || handled[0] != "first" || handled[1] != "second" {
+		t.Errorf("expected the two records to be handled, got %v", handled)
+	}
+
+	// the handler's records are written to the file, which doesn't fail
+	if records := read(); len(records) != 4 {
+		t.Errorf("expected 4 records, got %v", records)
+	}
+}
+
+func TestWithWriteErrorHandlerNoError(t *testing.T) {
+	ctx, read := newFileContext(t, WithWriteErrorHandler(func(err error, _ zapcore.Entry) {
+		t.Errorf("unexpected error: %v", err)
+	}))
+
+	Info(ctx, "x")
+
+	requireRecords(t, read(), 1)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// Writer returns an io.Writer that logs each newline-delimited line written to it at the
+// given level, with the given options. Partial lines are buffered until their newline
+// arrives; Close logs any pending partial line. For example:
+//
+//	w := clog.Writer(ctx, clog.InfoLevel, clog.WithField("cmd", "make"))
+//	defer w.Close()
+//
+//	cmd.Stdout = w
+func Writer(ctx context.Context, level Level, opts ...Option) io.WriteCloser {
+	// the records are annotated with the caller of Write or Close (see WithCaller)
+	return &lineWriter{ctx: skipCallers(ctx, 2), level: level, opts: opts}
+}
+
+type lineWriter struct {
+	mu    sync.Mutex
+	ctx   context.Context
+	level Level
+	opts  []Option
+	buf   []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.log(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.log(w.buf)
+		w.buf = nil
+	}
+
+	return nil
+}
+
+func (w *lineWriter) log(line []byte) {
+	Log(w.ctx, w.level, string(bytes.TrimSuffix(line, []byte{'\r'})), w.opts...)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Terminal Stream Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clog
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	ctx, read := newFileContext(t)
+
+	w := Writer(ctx, WarnLevel, WithField("cmd", "make"))
+
+	fmt.Fprint(w, "first li")
+	fmt.Fprint(w, "ne\r\nsecond line\nthi")
+	fmt.Fprint(w, "rd")
+
+	requireRecords(t, read(), 2)
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := read()
+	requireRecords(t, records, 3)
+
+	for i, msg := range []string{"first line", "second line", "third"} {
+		if records[i]["msg"] != msg || records[i]["severity"] != "WARN" || records[i]["cmd"] != "make" {
+			t.Errorf("unexpected record %d: %v", i, records[i])
+		}
+	}
+}